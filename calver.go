@@ -0,0 +1,370 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// calVerTokens maps the CalVer format tokens to the kind of value they hold,
+// see https://calver.org
+var calVerTokens = map[string]string{
+	"YYYY":  "year",
+	"YY":    "year",
+	"0Y":    "year",
+	"MM":    "month",
+	"0M":    "month",
+	"WW":    "week",
+	"0W":    "week",
+	"DD":    "day",
+	"0D":    "day",
+	"MAJOR": "major",
+	"MINOR": "minor",
+	"MICRO": "micro",
+}
+
+// CalVerFormat is a calendar versioning format like "YYYY.MM.MICRO".
+//
+// Valid tokens, separated by ".", are:
+//   - "YYYY" full year: 2006, 2016, 2106
+//   - "YY" short year: 6, 16, 106
+//   - "0Y" zero-padded year: 06, 16, 106
+//   - "MM" short month: 1, 2 ... 11, 12
+//   - "0M" zero-padded month: 01, 02 ... 11, 12
+//   - "WW" short ISO week: 1, 2, 33, 52
+//   - "0W" zero-padded ISO week: 01, 02, 33, 52
+//   - "DD" short day: 1, 2 ... 30, 31
+//   - "0D" zero-padded day: 01, 02 ... 30, 31
+//   - "MAJOR", "MINOR", "MICRO" incrementing numbers
+//
+// Short years count from 2000. Formats using weeks use the ISO year.
+type CalVerFormat struct {
+	layout string
+	tokens []string
+}
+
+// ParseCalVerFormat parses a CalVer format like "YYYY.0M.MICRO".
+// If the format could not be parsed an error is returned.
+func ParseCalVerFormat(layout string) (CalVerFormat, error) {
+	if len(layout) == 0 {
+		return CalVerFormat{}, errors.New("CalVer format empty")
+	}
+	kinds := map[string]bool{}
+	tokens := strings.Split(layout, ".")
+	for _, t := range tokens {
+		kind, ok := calVerTokens[t]
+		if !ok {
+			return CalVerFormat{}, fmt.Errorf("Unknown CalVer token %q in %q", t, layout)
+		}
+		if kinds[kind] {
+			return CalVerFormat{}, fmt.Errorf("Duplicate CalVer %s token %q in %q", kind, t, layout)
+		}
+		kinds[kind] = true
+	}
+	if kinds["week"] && (kinds["month"] || kinds["day"]) {
+		return CalVerFormat{}, fmt.Errorf("CalVer format %q can not combine weeks with months or days", layout)
+	}
+	return CalVerFormat{layout: layout, tokens: tokens}, nil
+}
+
+// MustParseCalVerFormat is like ParseCalVerFormat but panics if the format cannot be parsed.
+func MustParseCalVerFormat(layout string) CalVerFormat {
+	f, err := ParseCalVerFormat(layout)
+	if err != nil {
+		panic(`semver: ParseCalVerFormat(` + layout + `): ` + err.Error())
+	}
+	return f
+}
+
+// CalVerFormat to string
+func (f CalVerFormat) String() string {
+	return f.layout
+}
+
+// index returns the position of the token of the given kind or -1.
+func (f CalVerFormat) index(kind string) int {
+	for i, t := range f.tokens {
+		if calVerTokens[t] == kind {
+			return i
+		}
+	}
+	return -1
+}
+
+// CalVer is a calendar version like "2024.10.2" or "24.04" in a CalVerFormat.
+type CalVer struct {
+	Format CalVerFormat
+	// Values holds the numbers of the format tokens, years as full year.
+	Values []uint64
+	// Pre is the optional modifier like "hotfix.1" in "2024.10.16-hotfix.1",
+	// it is compared like a semver prerelease.
+	Pre []PRVersion
+}
+
+// ParseCalVer parses a calendar version using the format layout.
+// If the format or the version could not be parsed an error is returned.
+func ParseCalVer(layout, s string) (CalVer, error) {
+	f, err := ParseCalVerFormat(layout)
+	if err != nil {
+		return CalVer{}, err
+	}
+	return f.Parse(s)
+}
+
+// NewCalVer creates the first calendar version of the format for the date t,
+// with all of MAJOR, MINOR and MICRO being 0.
+// An error is returned if the year of t can not be represented by the
+// format, like years before 2000 as short year.
+func NewCalVer(f CalVerFormat, t time.Time) (CalVer, error) {
+	c := CalVer{Format: f, Values: make([]uint64, len(f.tokens))}
+	if err := c.setDate(t); err != nil {
+		return CalVer{}, err
+	}
+	return c, nil
+}
+
+// Parse parses a calendar version validated against the format f.
+func (f CalVerFormat) Parse(s string) (CalVer, error) {
+	if len(f.tokens) == 0 {
+		return CalVer{}, errors.New("CalVer format empty")
+	}
+	if len(s) == 0 {
+		return CalVer{}, errors.New("Version string empty")
+	}
+
+	c := CalVer{Format: f}
+	if i := strings.IndexRune(s, '-'); i != -1 {
+		for _, prstr := range strings.Split(s[i+1:], ".") {
+			parsedPR, err := NewPRVersion(prstr)
+			if err != nil {
+				return CalVer{}, err
+			}
+			c.Pre = append(c.Pre, parsedPR)
+		}
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != len(f.tokens) {
+		return CalVer{}, fmt.Errorf("Version %q does not match CalVer format %q", s, f.layout)
+	}
+	for i, p := range parts {
+		n, err := parseCalVerValue(f.tokens[i], p)
+		if err != nil {
+			return CalVer{}, err
+		}
+		c.Values = append(c.Values, n)
+	}
+
+	// Reject dates like 2023.02.30
+	if y, m, d := f.index("year"), f.index("month"), f.index("day"); y != -1 && m != -1 && d != -1 {
+		t := time.Date(int(c.Values[y]), time.Month(c.Values[m]), int(c.Values[d]), 0, 0, 0, 0, time.UTC)
+		if t.Day() != int(c.Values[d]) {
+			return CalVer{}, fmt.Errorf("Invalid date in %q", s)
+		}
+	}
+	return c, nil
+}
+
+// parseCalVerValue parses the value of a single token.
+func parseCalVerValue(token, s string) (uint64, error) {
+	if !containsOnly(s, numbers) || len(s) == 0 {
+		return 0, fmt.Errorf("Invalid character(s) found in %s %q", token, s)
+	}
+	padded := strings.HasPrefix(token, "0")
+	if padded && len(s) < 2 {
+		return 0, fmt.Errorf("%s must be zero-padded %q", token, s)
+	}
+	if (!padded || len(s) > 2) && hasLeadingZeroes(s) {
+		return 0, fmt.Errorf("%s must not contain leading zeroes %q", token, s)
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	var min, max uint64
+	switch calVerTokens[token] {
+	case "year":
+		if token == "YYYY" {
+			min, max = 1000, 9999
+		} else {
+			n += 2000
+		}
+	case "month":
+		min, max = 1, 12
+	case "week":
+		min, max = 1, 53
+	case "day":
+		min, max = 1, 31
+	}
+	if max > 0 && (n < min || n > max) {
+		return 0, fmt.Errorf("%s out of range %q", token, s)
+	}
+	return n, nil
+}
+
+// CalVer to string
+func (c CalVer) String() string {
+	b := make([]byte, 0, 16)
+	for i, n := range c.Values {
+		if i > 0 {
+			b = append(b, '.')
+		}
+		t := ""
+		if i < len(c.Format.tokens) {
+			t = c.Format.tokens[i]
+		}
+		if calVerTokens[t] == "year" && t != "YYYY" && n >= 2000 {
+			n -= 2000
+		}
+		if strings.HasPrefix(t, "0") && n < 10 {
+			b = append(b, '0')
+		}
+		b = strconv.AppendUint(b, n, 10)
+	}
+
+	if len(c.Pre) > 0 {
+		b = append(b, '-')
+		b = append(b, c.Pre[0].String()...)
+
+		for _, pre := range c.Pre[1:] {
+			b = append(b, '.')
+			b = append(b, pre.String()...)
+		}
+	}
+	return string(b)
+}
+
+// Compare compares CalVers c to o of the same format:
+// -1 == c is less than o
+// 0 == c is equal to o
+// 1 == c is greater than o
+func (c CalVer) Compare(o CalVer) int {
+	for i := 0; i < len(c.Values) && i < len(o.Values); i++ {
+		if c.Values[i] != o.Values[i] {
+			if c.Values[i] > o.Values[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+	if len(c.Values) != len(o.Values) {
+		if len(c.Values) > len(o.Values) {
+			return 1
+		}
+		return -1
+	}
+	return comparePre(c.Pre, o.Pre)
+}
+
+// EQ checks if c is equal to o.
+func (c CalVer) EQ(o CalVer) bool {
+	return (c.Compare(o) == 0)
+}
+
+// GT checks if c is greater than o.
+func (c CalVer) GT(o CalVer) bool {
+	return (c.Compare(o) == 1)
+}
+
+// LT checks if c is less than o.
+func (c CalVer) LT(o CalVer) bool {
+	return (c.Compare(o) == -1)
+}
+
+// Version converts c to a Version keeping the order of calendar versions:
+// "2024.10.2" becomes 2024.10.2 and "24.04" becomes 24.4.0.
+// Formats with more than three tokens can not be converted.
+func (c CalVer) Version() (Version, error) {
+	if len(c.Values) != len(c.Format.tokens) {
+		return Version{}, fmt.Errorf("CalVer %q does not match the format %q", c.String(), c.Format.String())
+	}
+	if len(c.Values) > 3 {
+		return Version{}, fmt.Errorf("CalVer %q with more than three components can not be converted", c.String())
+	}
+	var nums [3]uint64
+	for i, t := range c.Format.tokens {
+		nums[i] = c.Values[i]
+		if calVerTokens[t] == "year" && t != "YYYY" {
+			if nums[i] < 2000 {
+				return Version{}, fmt.Errorf("Year %d of CalVer %q can not be represented as %s", nums[i], c.String(), t)
+			}
+			nums[i] -= 2000
+		}
+	}
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2], Pre: c.Pre}, nil
+}
+
+// Bump updates c for a release at the date t and drops the modifier.
+// If the date changed, the date tokens are set to t and MICRO is reset to 0,
+// otherwise MICRO is incremented. An error is returned and c is left
+// unchanged if the date of t is before the date of c, if the date did not
+// change and the format has no MICRO token, if the year of t can not be
+// represented by the format or if the Values do not match the format.
+func (c *CalVer) Bump(t time.Time) error {
+	if len(c.Values) != len(c.Format.tokens) {
+		return fmt.Errorf("CalVer %q does not match the format %q", c.String(), c.Format.String())
+	}
+	n, err := NewCalVer(c.Format, t)
+	if err != nil {
+		return err
+	}
+	for i, tok := range c.Format.tokens {
+		if kind := calVerTokens[tok]; kind == "major" || kind == "minor" {
+			n.Values[i] = c.Values[i]
+		}
+	}
+
+	micro := c.Format.index("micro")
+	comp := 0
+	for i, tok := range c.Format.tokens {
+		switch calVerTokens[tok] {
+		case "year", "month", "week", "day":
+			if n.Values[i] != c.Values[i] && comp == 0 {
+				if n.Values[i] > c.Values[i] {
+					comp = 1
+				} else {
+					comp = -1
+				}
+			}
+		}
+	}
+	switch {
+	case comp < 0:
+		return fmt.Errorf("Date %s is before CalVer %q", t.Format("2006-01-02"), c.String())
+	case comp == 0 && micro == -1:
+		return fmt.Errorf("CalVer %q can not be bumped on the same date without MICRO", c.String())
+	case comp == 0:
+		n.Values[micro] = c.Values[micro] + 1
+	}
+	*c = n
+	return nil
+}
+
+// setDate sets the date tokens to the date t.
+// An error is returned if the year can not be represented by the format.
+func (c *CalVer) setDate(t time.Time) error {
+	year, week := t.ISOWeek()
+	if c.Format.index("week") == -1 {
+		year = t.Year()
+	}
+	for i, tok := range c.Format.tokens {
+		switch calVerTokens[tok] {
+		case "year":
+			if (tok == "YYYY" && (year < 1000 || year > 9999)) || (tok != "YYYY" && year < 2000) {
+				return fmt.Errorf("Year %d can not be represented as %s", year, tok)
+			}
+			c.Values[i] = uint64(year)
+		case "month":
+			c.Values[i] = uint64(t.Month())
+		case "week":
+			c.Values[i] = uint64(week)
+		case "day":
+			c.Values[i] = uint64(t.Day())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package semver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCalVerFormat(t *testing.T) {
+	valid := []string{"YYYY.MM.MICRO", "YY.0M", "YYYY.0W", "0Y.0M.0D", "YYYY.MAJOR.MINOR.MICRO", "MAJOR.YY.WW"}
+	for _, layout := range valid {
+		f, err := ParseCalVerFormat(layout)
+		if err != nil {
+			t.Errorf("Error parsing CalVer format %q: %q", layout, err)
+		} else if f.String() != layout {
+			t.Errorf("Expected format %q, got %q", layout, f)
+		}
+	}
+	invalid := []string{"", "YYYY.MM.", "YYY.MM", "YYYY.YY", "YYYY.MM.0M", "YYYY.WW.DD", "yyyy.mm", "YYYY-MM"}
+	for _, layout := range invalid {
+		if _, err := ParseCalVerFormat(layout); err == nil {
+			t.Errorf("Parsing CalVer format %q, expected error but got none", layout)
+		}
+	}
+}
+
+func TestParseCalVer(t *testing.T) {
+	tests := []struct {
+		layout  string
+		s       string
+		version string
+	}{
+		{"YYYY.MM.MICRO", "2024.10.2", "2024.10.2"},
+		{"YY.0M", "24.04", "24.4.0"},
+		{"YYYY.0W", "2024.07", "2024.7.0"},
+		{"YYYY.0M.0D", "2024.10.16-hotfix.1", "2024.10.16-hotfix.1"},
+		{"YYYY.MM.DD", "2024.2.29", "2024.2.29"},
+		{"0Y.0M.MICRO", "06.01.0", "6.1.0"},
+		{"YY.MM.MICRO", "106.1.3", "106.1.3"},
+		{"YYYY.MAJOR.MINOR.MICRO", "2024.1.2.3", ""},
+		// Errors
+		{"YYYY.MM.MICRO", "2024.10", "error"},
+		{"YYYY.MM.MICRO", "2024.10.2.1", "error"},
+		{"YYYY.MM.MICRO", "24.10.2", "error"},
+		{"YYYY.MM.MICRO", "2024.13.0", "error"},
+		{"YYYY.MM.MICRO", "2024.0.0", "error"},
+		{"YYYY.MM.MICRO", "2024.01.0", "error"},
+		{"YYYY.MM.MICRO", "2024.1.01", "error"},
+		{"YY.0M", "24.4", "error"},
+		{"YY.0M", "024.04", "error"},
+		{"0Y.0M", "006.04", "error"},
+		{"YYYY.0W", "2024.54", "error"},
+		{"YYYY.0M.0D", "2023.02.29", "error"},
+		{"YYYY.0M.0D", "2024.04.31", "error"},
+		{"YYYY.MM.MICRO", "2024.x.1", "error"},
+		{"YYYY.MM.MICRO", "2024.10.1-", "error"},
+		{"YYYY.MM.MICRO", "", "error"},
+	}
+
+	for _, test := range tests {
+		c, err := ParseCalVer(test.layout, test.s)
+		if err != nil {
+			if test.version != "error" {
+				t.Errorf("Error parsing %q as %q: %q", test.s, test.layout, err)
+			}
+			continue
+		}
+		if test.version == "error" {
+			t.Errorf("Parsing %q as %q, expected error but got %q", test.s, test.layout, c)
+			continue
+		}
+		if c.String() != test.s {
+			t.Errorf("Parsing %q as %q, expected string %q but got %q", test.s, test.layout, test.s, c)
+		}
+		v, err := c.Version()
+		if test.version == "" {
+			if err == nil {
+				t.Errorf("Converting %q, expected error but got %q", test.s, v)
+			}
+		} else if err != nil {
+			t.Errorf("Converting %q, unexpected error %q", test.s, err)
+		} else if v.String() != test.version {
+			t.Errorf("Converting %q, expected %q but got %q", test.s, test.version, v)
+		}
+	}
+}
+
+func TestCalVerCompare(t *testing.T) {
+	f := MustParseCalVerFormat("YYYY.0M.0D.MICRO")
+	ordered := []string{
+		"2023.12.31.5",
+		"2024.01.02.0",
+		"2024.10.09.0",
+		"2024.10.16.0-hotfix.1",
+		"2024.10.16.0",
+		"2024.10.16.1",
+		"2024.10.16.10",
+	}
+	for i := 1; i < len(ordered); i++ {
+		a, err := f.Parse(ordered[i-1])
+		if err != nil {
+			t.Fatalf("Error parsing %q: %q", ordered[i-1], err)
+		}
+		b, err := f.Parse(ordered[i])
+		if err != nil {
+			t.Fatalf("Error parsing %q: %q", ordered[i], err)
+		}
+		if !a.LT(b) || !b.GT(a) || a.EQ(b) {
+			t.Errorf("%q should be less than %q", a, b)
+		}
+		if !b.EQ(b) {
+			t.Errorf("%q should be equal to itself", b)
+		}
+		va, _ := a.Version()
+		vb, _ := b.Version()
+		if len(a.Values) <= 3 && !va.LT(vb) {
+			t.Errorf("Version %q should be less than %q", va, vb)
+		}
+	}
+}
+
+func TestCalVerBump(t *testing.T) {
+	day := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	tests := []struct {
+		layout string
+		s      string
+		date   string
+		o      string
+	}{
+		{"YYYY.MM.MICRO", "2024.10.2", "2024-10-16", "2024.10.3"},
+		{"YYYY.MM.MICRO", "2024.10.2", "2024-11-01", "2024.11.0"},
+		{"YYYY.MM.MICRO", "2024.10.2-rc.1", "2024-10-16", "2024.10.3"},
+		{"YY.0M.0D.MICRO", "24.10.16.0", "2024-10-16", "24.10.16.1"},
+		{"YY.0M.0D.MICRO", "24.10.16.3", "2024-10-17", "24.10.17.0"},
+		{"YYYY.0W.MICRO", "2024.52.1", "2024-12-30", "2025.01.0"},
+		{"MAJOR.YYYY.MICRO", "3.2023.4", "2024-01-01", "3.2024.0"},
+		{"YYYY.MINOR.MICRO", "2024.2.4", "2024-05-01", "2024.2.5"},
+		// Errors
+		{"YYYY.MM.MICRO", "2024.10.2", "2024-09-30", ""},
+		{"YY.0M", "24.10", "2024-10-20", ""},
+		{"YY.0M.MICRO", "0.01.0", "1999-12-31", ""},
+		{"0Y.0W.MICRO", "00.01.0", "2000-01-01", ""},
+	}
+
+	for _, test := range tests {
+		c, err := ParseCalVer(test.layout, test.s)
+		if err != nil {
+			t.Errorf("Error parsing %q as %q: %q", test.s, test.layout, err)
+			continue
+		}
+		err = c.Bump(day(test.date))
+		if err != nil {
+			if test.o != "" {
+				t.Errorf("Bumping %q on %s, expected %q but got error %q", test.s, test.date, test.o, err)
+			} else if c.String() != test.s {
+				t.Errorf("Bumping %q on %s, expected unchanged version but got %q", test.s, test.date, c)
+			}
+			continue
+		}
+		if test.o == "" {
+			t.Errorf("Bumping %q on %s, expected error but got %q", test.s, test.date, c)
+		} else if c.String() != test.o {
+			t.Errorf("Bumping %q on %s, expected %q but got %q", test.s, test.date, test.o, c)
+		}
+	}
+
+	newTests := []struct {
+		layout string
+		date   string
+		o      string
+	}{
+		{"YYYY.0M.MICRO", "2024-03-05", "2024.03.0"},
+		{"YY.0M.MICRO", "2000-01-01", "0.01.0"},
+		{"0Y.0W", "2024-12-30", "25.01"},
+		// Errors
+		{"YY.0M.MICRO", "1999-05-01", ""},
+		{"0Y.MM", "1970-01-01", ""},
+		{"YYYY.MM", "0999-01-01", ""},
+	}
+	for _, test := range newTests {
+		c, err := NewCalVer(MustParseCalVerFormat(test.layout), day(test.date))
+		if err != nil {
+			if test.o != "" {
+				t.Errorf("Creating %q on %s, expected %q but got error %q", test.layout, test.date, test.o, err)
+			}
+		} else if test.o == "" {
+			t.Errorf("Creating %q on %s, expected error but got %q", test.layout, test.date, c)
+		} else if c.String() != test.o {
+			t.Errorf("Creating %q on %s, expected %q but got %q", test.layout, test.date, test.o, c)
+		}
+	}
+
+	// Values not matching the format must not panic
+	c := CalVer{Format: MustParseCalVerFormat("YYYY.MM.MICRO")}
+	if err := c.Bump(day("2024-03-05")); err == nil {
+		t.Errorf("Expected error bumping CalVer without values, got %q", c)
+	}
+	c = CalVer{Format: MustParseCalVerFormat("YY.MM"), Values: []uint64{2024, 3, 1}}
+	if err := c.Bump(day("2024-03-05")); err == nil {
+		t.Errorf("Expected error bumping CalVer with too many values, got %q", c)
+	}
+	if _, err := c.Version(); err == nil {
+		t.Errorf("Expected error converting CalVer with too many values")
+	}
+	if c.String() != "24.3.1" {
+		t.Errorf("Expected %q, got %q", "24.3.1", c)
+	}
+	c = CalVer{Format: MustParseCalVerFormat("YY.MM"), Values: []uint64{1999, 3}}
+	if _, err := c.Version(); err == nil {
+		t.Errorf("Expected error converting CalVer with year 1999 as short year")
+	}
+}
@@ -0,0 +1,82 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+// CoerceOptions control how Coerce extracts a version.
+type CoerceOptions struct {
+	// RightMost returns the right-most version instead of the first one,
+	// so "1.2.3.4" is coerced to 2.3.4 instead of 1.2.3.
+	RightMost bool
+}
+
+// Coerce extracts the first plausible version from strings like "v2",
+// "release-1.4" or "nginx/1.25.3 (Ubuntu)". Missing minor and patch
+// numbers are filled up with 0, prerelease and build meta data are ignored.
+// Each number can have at most 16 digits.
+// If no version is found an error is returned.
+func Coerce(s string) (Version, error) {
+	return CoerceWithOptions(s, CoerceOptions{})
+}
+
+// CoerceWithOptions extracts a version like Coerce using the given options.
+func CoerceWithOptions(s string, opts CoerceOptions) (Version, error) {
+	re := getRegex()["COERCE"]
+
+	var match []string
+	if !opts.RightMost {
+		match = re.FindStringSubmatch(s)
+	} else {
+		// Like node-semver, the match ending right-most wins,
+		// the longest one if several end there
+		end := -1
+		for i, r := range s {
+			if !unicode.IsDigit(r) || (i > 0 && unicode.IsDigit(rune(s[i-1]))) {
+				continue
+			}
+			start := i
+			if i > 0 {
+				// Keep the preceding non digit for the COERCE prefix
+				start = i - 1
+			}
+			m := re.FindStringSubmatchIndex(s[start:])
+			if m == nil || m[0] != 0 {
+				continue
+			}
+			numEnd := m[3]
+			for _, e := range []int{m[5], m[7]} {
+				if e > numEnd {
+					numEnd = e
+				}
+			}
+			if start+numEnd > end {
+				end = start + numEnd
+				match = make([]string, 4)
+				for g := 1; g < 4; g++ {
+					if m[2*g] != -1 {
+						match[g] = s[start+m[2*g] : start+m[2*g+1]]
+					}
+				}
+			}
+		}
+	}
+	if match == nil {
+		return Version{}, fmt.Errorf("No version found in %q", s)
+	}
+
+	var nums [3]uint64
+	for i, n := range match[1:4] {
+		if len(n) == 0 {
+			continue
+		}
+		num, err := strconv.ParseUint(n, 10, 64)
+		if err != nil {
+			return Version{}, err
+		}
+		nums[i] = num
+	}
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}, nil
+}
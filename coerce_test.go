@@ -0,0 +1,58 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		i         string
+		o         string
+		rightMost string
+	}{
+		{"1.2.3", "1.2.3", "1.2.3"},
+		{"v2", "2.0.0", "2.0.0"},
+		{"V2.1", "2.1.0", "2.1.0"},
+		{"release-1.4", "1.4.0", "1.4.0"},
+		{"nginx/1.25.3 (Ubuntu)", "1.25.3", "1.25.3"},
+		{"1.2.3-rc.1+build.5", "1.2.3", "5.0.0"},
+		{"  01.002.0003  ", "1.2.3", "1.2.3"},
+		{"1.2.3.4", "1.2.3", "2.3.4"},
+		{"1.2.3/4.5.6", "1.2.3", "4.5.6"},
+		{"1.2 3.4 5", "1.2.0", "5.0.0"},
+		{"10.20.30abc", "10.20.30", "10.20.30"},
+		{"version 42 of 43.1", "42.0.0", "43.1.0"},
+		{"1234567890123456", "1234567890123456.0.0", "1234567890123456.0.0"},
+		{"1.2." + strings.Repeat("9", 17), "1.2.0", "1.2.0"},
+		// Errors
+		{"", "", ""},
+		{"version", "", ""},
+		{".a.b", "", ""},
+		{strings.Repeat("1", 17), "", ""},
+	}
+
+	for _, tc := range tests {
+		v, err := Coerce(tc.i)
+		if err != nil {
+			if tc.o != "" {
+				t.Errorf("Invalid for case %q: Expected %q, got error %q", tc.i, tc.o, err)
+			}
+		} else if tc.o == "" {
+			t.Errorf("Invalid for case %q: Expected error, got %q", tc.i, v)
+		} else if v.String() != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, v)
+		}
+
+		v, err = CoerceWithOptions(tc.i, CoerceOptions{RightMost: true})
+		if err != nil {
+			if tc.rightMost != "" {
+				t.Errorf("Invalid right-most for case %q: Expected %q, got error %q", tc.i, tc.rightMost, err)
+			}
+		} else if tc.rightMost == "" {
+			t.Errorf("Invalid right-most for case %q: Expected error, got %q", tc.i, v)
+		} else if v.String() != tc.rightMost {
+			t.Errorf("Invalid right-most for case %q: Expected %q, got: %q", tc.i, tc.rightMost, v)
+		}
+	}
+}
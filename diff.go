@@ -0,0 +1,71 @@
+package semver
+
+// Diff returns the kind of change between two versions like node-semver's
+// diff, regardless of their order:
+//   - "major", "minor" or "patch" if the higher version is a release
+//   - "premajor", "preminor" or "prepatch" if the higher version is a prerelease
+//   - "prerelease" if only the prereleases differ
+//   - "build" if only the build meta data differs
+//   - "" (ReleaseNone) if both versions are identical
+//
+// Releasing a prerelease is reported as the release it leads to,
+// so 1.0.0-1 to 1.0.0 is "major" and 1.2.3-1 to 1.2.3 is "patch".
+func Diff(a, b Version) ReleaseType {
+	comp := a.Compare(b)
+	if comp == 0 {
+		if !buildEqual(a.Build, b.Build) {
+			return ReleaseBuild
+		}
+		return ReleaseNone
+	}
+	high, low := a, b
+	if comp < 0 {
+		high, low = b, a
+	}
+
+	if len(low.Pre) > 0 && len(high.Pre) == 0 {
+		// Going from a prerelease to a release depends on the release
+		// the prerelease leads to
+		if low.Minor == 0 && low.Patch == 0 {
+			return ReleaseMajor
+		}
+		if low.Major == high.Major && low.Minor == high.Minor && low.Patch == high.Patch {
+			if low.Patch == 0 {
+				return ReleaseMinor
+			}
+			return ReleasePatch
+		}
+	}
+
+	switch {
+	case a.Major != b.Major:
+		if len(high.Pre) > 0 {
+			return ReleasePremajor
+		}
+		return ReleaseMajor
+	case a.Minor != b.Minor:
+		if len(high.Pre) > 0 {
+			return ReleasePreminor
+		}
+		return ReleaseMinor
+	case a.Patch != b.Patch:
+		if len(high.Pre) > 0 {
+			return ReleasePrepatch
+		}
+		return ReleasePatch
+	}
+	return ReleasePrerelease
+}
+
+// buildEqual checks if two build meta data lists are identical.
+func buildEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a string
+		b string
+		o ReleaseType
+	}{
+		{"1.2.3", "0.2.3", ReleaseMajor},
+		{"0.2.3", "1.2.3", ReleaseMajor},
+		{"1.4.5", "0.2.3", ReleaseMajor},
+		{"1.2.3", "2.0.0-pre", ReleasePremajor},
+		{"1.2.3", "1.3.3", ReleaseMinor},
+		{"1.0.1", "1.1.0-pre", ReleasePreminor},
+		{"1.2.3", "1.2.4", ReleasePatch},
+		{"1.2.3", "1.2.4-pre", ReleasePrepatch},
+		{"0.0.1", "0.0.1-pre", ReleasePatch},
+		{"0.0.1", "0.0.1-pre-2", ReleasePatch},
+		{"1.1.0", "1.1.0-pre", ReleaseMinor},
+		{"1.1.0-pre-1", "1.1.0-pre-2", ReleasePrerelease},
+		{"1.0.0", "1.0.0", ReleaseNone},
+		{"1.0.0-1", "1.0.0-1", ReleaseNone},
+		{"1.0.0+build.1", "1.0.0+build.1", ReleaseNone},
+		{"1.0.0+build.1", "1.0.0+build.2", ReleaseBuild},
+		{"1.0.0", "1.0.0+build.2", ReleaseBuild},
+		{"1.0.0-rc.1+a", "1.0.0-rc.1+b", ReleaseBuild},
+		{"0.0.2-1", "0.0.2", ReleasePatch},
+		{"1.0.0-1", "1.0.0", ReleaseMajor},
+		{"1.0.0-1", "1.1.1", ReleaseMajor},
+		{"1.0.0-1", "2.1.1", ReleaseMajor},
+		{"1.0.1-1", "1.0.1", ReleasePatch},
+		{"0.0.0-1", "0.0.0", ReleaseMajor},
+		{"1.0.0-1", "2.0.0", ReleaseMajor},
+		{"1.0.0-1", "2.0.0-1", ReleasePremajor},
+		{"1.0.0-1", "1.1.0-1", ReleasePreminor},
+		{"1.0.0-1", "1.0.1-1", ReleasePrepatch},
+		{"1.1.0-1", "1.1.0", ReleaseMinor},
+		{"1.1.0-1", "1.2.0", ReleaseMinor},
+		{"1.1.1-1", "1.2.0", ReleaseMinor},
+		{"1.1.1-1", "1.1.2", ReleasePatch},
+	}
+
+	for _, tc := range tests {
+		if o := Diff(MustParse(tc.a), MustParse(tc.b)); o != tc.o {
+			t.Errorf("Invalid for case %q, %q: Expected %q, got: %q", tc.a, tc.b, tc.o, o)
+		}
+	}
+}
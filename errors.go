@@ -0,0 +1,92 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Sentinel errors wrapped by ParseError, usable with errors.Is.
+var (
+	// ErrEmpty is returned for an empty version or component.
+	ErrEmpty = errors.New("empty")
+	// ErrMissingComponent is returned if major, minor or patch number is missing.
+	ErrMissingComponent = errors.New("missing component")
+	// ErrLeadingZero is returned for numbers with leading zeroes.
+	ErrLeadingZero = errors.New("leading zero")
+	// ErrInvalidChar is returned for characters not allowed in a component.
+	ErrInvalidChar = errors.New("invalid character")
+	// ErrOverflow is returned for numbers exceeding uint64.
+	ErrOverflow = errors.New("number overflow")
+	// ErrTooLong is returned for version strings exceeding ParseOptions.MaxLength.
+	ErrTooLong = errors.New("too long")
+)
+
+// ParseError is returned if a version string could not be parsed.
+type ParseError struct {
+	// Input is the parsed string.
+	Input string
+	// Component is the failing part of the version: "major", "minor",
+	// "patch", "prerelease", "build" or "" for the whole version.
+	// Further components of a MultiVersion are named "component 4" etc.
+	Component string
+	// Offset is the byte offset of the failing component within Input.
+	Offset int
+	// Err is one of the sentinel errors like ErrInvalidChar.
+	Err error
+
+	msg string
+}
+
+// Error returns the error message.
+func (e *ParseError) Error() string {
+	if len(e.msg) > 0 {
+		return e.msg
+	}
+	if len(e.Component) == 0 {
+		return fmt.Sprintf("Could not parse version %q: %s", e.Input, e.Err)
+	}
+	return fmt.Sprintf("Could not parse %s of version %q at offset %d: %s", e.Component, e.Input, e.Offset, e.Err)
+}
+
+// Unwrap returns the sentinel error, so errors.Is can be used.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// newParseError creates a ParseError with the given message.
+func newParseError(input, component string, offset int, err error, msg string) *ParseError {
+	return &ParseError{Input: input, Component: component, Offset: offset, Err: err, msg: msg}
+}
+
+// relocateParseError moves the ParseError of a single identifier into the
+// version string input at offset. Other errors are returned unchanged.
+func relocateParseError(err error, input string, offset int) error {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		pe.Input, pe.Offset = input, offset
+	}
+	return err
+}
+
+// parseNumber parses a major, minor, patch or prerelease number,
+// mapping strconv errors to ParseErrors.
+func parseNumber(input, component string, offset int, s string) (uint64, error) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err == nil {
+		return n, nil
+	}
+	if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+		name := component
+		if len(name) > 0 {
+			name = strings.ToUpper(name[:1]) + name[1:]
+		}
+		return 0, newParseError(input, component, offset, ErrOverflow, fmt.Sprintf("%s number %q exceeds uint64", name, s))
+	}
+	kind := ErrInvalidChar
+	if len(s) == 0 {
+		kind = ErrEmpty
+	}
+	return 0, newParseError(input, component, offset, kind, err.Error())
+}
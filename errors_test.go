@@ -0,0 +1,103 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		s         string
+		err       error
+		component string
+		offset    int
+		msg       string
+	}{
+		{"", ErrEmpty, "", 0, "Version string empty"},
+		{"1.2", ErrMissingComponent, "", 0, "No Major.Minor.Patch elements found"},
+		{"a.2.3", ErrInvalidChar, "major", 0, `Invalid character(s) found in major number "a"`},
+		{"v01.2.3", ErrLeadingZero, "major", 1, `Major number must not contain leading zeroes "01"`},
+		{"1.b.3", ErrInvalidChar, "minor", 2, `Invalid character(s) found in minor number "b"`},
+		{"1..3", ErrEmpty, "minor", 2, `strconv.ParseUint: parsing "": invalid syntax`},
+		{"1.2.03", ErrLeadingZero, "patch", 4, `Patch number must not contain leading zeroes "03"`},
+		{"99999999999999999999.1.2", ErrOverflow, "major", 0, `Major number "99999999999999999999" exceeds uint64`},
+		{"1.2.99999999999999999999", ErrOverflow, "patch", 4, `Patch number "99999999999999999999" exceeds uint64`},
+		{"1.2.3-rc.01", ErrLeadingZero, "prerelease", 9, `Numeric PreRelease version must not contain leading zeroes "01"`},
+		{"1.2.3-rc..1", ErrEmpty, "prerelease", 9, "Prerelease is empty"},
+		{"1.2.3-r$c", ErrInvalidChar, "prerelease", 6, `Invalid character(s) found in prerelease "r$c"`},
+		{"1.2.3-1.20000000000000000000", ErrOverflow, "prerelease", 8, `Prerelease number "20000000000000000000" exceeds uint64`},
+		{"1.2.3+build..1", ErrEmpty, "build", 12, "Build meta data is empty"},
+		{"1.2.3-rc+b.u$", ErrInvalidChar, "build", 11, `Invalid character(s) found in build meta data "u$"`},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(test.s)
+		if err == nil {
+			t.Errorf("Parsing %q, expected error but got none", test.s)
+			continue
+		}
+		if !errors.Is(err, test.err) {
+			t.Errorf("Parsing %q, expected error %q but got %q", test.s, test.err, err)
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("Parsing %q, expected ParseError but got %T", test.s, err)
+			continue
+		}
+		if pe.Input != test.s || pe.Component != test.component || pe.Offset != test.offset {
+			t.Errorf("Parsing %q, expected component %q at %d but got %q at %d of %q", test.s, test.component, test.offset, pe.Component, pe.Offset, pe.Input)
+		}
+		if pe.Error() != test.msg {
+			t.Errorf("Parsing %q, expected message %q but got %q", test.s, test.msg, pe.Error())
+		}
+	}
+
+	optsTests := []struct {
+		s         string
+		opts      ParseOptions
+		err       error
+		input     string
+		component string
+		offset    int
+		msg       string
+	}{
+		{"1.2.3-rc.1", ParseOptions{MaxLength: 5}, ErrTooLong, "1.2.3-rc.1", "", 5, "Version string longer than 5 characters"},
+		{"1.2-rc.1", ParseOptions{AllowShort: true}, ErrMissingComponent, "1.2-rc.1", "", 0, "Short version cannot contain PreRelease/Build meta data"},
+		{"v1+build", ParseOptions{AllowPrefix: true, AllowShort: true}, ErrMissingComponent, "1+build", "", 0, "Short version cannot contain PreRelease/Build meta data"},
+		{"v1.2.3", ParseOptions{}, ErrInvalidChar, "v1.2.3", "major", 0, `Prefix "v" not allowed in "v1.2.3"`},
+	}
+	for _, test := range optsTests {
+		_, err := ParseWithOptions(test.s, test.opts)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Parsing %q, expected error %q but got %v", test.s, test.err, err)
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("Parsing %q, expected ParseError but got %T", test.s, err)
+			continue
+		}
+		if pe.Input != test.input || pe.Component != test.component || pe.Offset != test.offset {
+			t.Errorf("Parsing %q, expected component %q at %d of %q but got %q at %d of %q", test.s, test.component, test.offset, test.input, pe.Component, pe.Offset, pe.Input)
+		}
+		if pe.Error() != test.msg {
+			t.Errorf("Parsing %q, expected message %q but got %q", test.s, test.msg, pe.Error())
+		}
+	}
+}
+
+func TestParseErrorHelpers(t *testing.T) {
+	if _, err := NewPRVersion("01"); !errors.Is(err, ErrLeadingZero) {
+		t.Errorf("Expected ErrLeadingZero, got %q", err)
+	}
+	if _, err := NewBuildVersion(""); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %q", err)
+	}
+	if _, err := ParseWithOptions("v1.2.3", ParseOptions{}); !errors.Is(err, ErrInvalidChar) {
+		t.Errorf("Expected ErrInvalidChar, got %q", err)
+	}
+
+	pe := &ParseError{Input: "1.2.x", Component: "patch", Offset: 4, Err: ErrInvalidChar}
+	if msg := pe.Error(); msg != `Could not parse patch of version "1.2.x" at offset 4: invalid character` {
+		t.Errorf("Unexpected message %q", msg)
+	}
+}
@@ -0,0 +1,108 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format implements the fmt.Formatter interface.
+//
+// The verbs %s and %v print the version like String, %q prints it quoted.
+// %#v prints the Go syntax representation of the Version.
+// For %s and %q the flag '#' adds a "v" prefix and the precision selects
+// the parts:
+//   - %.1s major: "1"
+//   - %.2s major and minor: "1.2"
+//   - %.3s major, minor and patch: "1.2.3"
+//   - %.4s without build meta data: "1.2.3-rc.1"
+//   - %s complete version: "1.2.3-rc.1+build.5"
+//   - %#.2s "v1.2", %#s "v1.2.3-rc.1+build.5"
+//
+// Width and the '-' flag pad the version like for strings.
+func (v Version) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprintf(f, "semver.Version{Major:%#v, Minor:%#v, Patch:%#v, Pre:%#v, Build:%#v}",
+				v.Major, v.Minor, v.Patch, v.Pre, v.Build)
+			return
+		}
+	case 's', 'q':
+	default:
+		fmt.Fprintf(f, "%%!%c(semver.Version=%s)", verb, v.String())
+		return
+	}
+
+	s := v.String()
+	if p, ok := f.Precision(); ok {
+		nums := []uint64{v.Major, v.Minor, v.Patch}
+		if p < len(nums) {
+			parts := make([]string, 0, p)
+			for _, n := range nums[:p] {
+				parts = append(parts, strconv.FormatUint(n, 10))
+			}
+			s = strings.Join(parts, ".")
+		} else {
+			w := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+			if p > len(nums) {
+				w.Pre = v.Pre
+			}
+			s = w.String()
+		}
+	}
+	if f.Flag('#') {
+		s = "v" + s
+	}
+	formatPadded(f, verb, s)
+}
+
+// formatPadded writes s to f, padded to the width of f and quoted for %q.
+func formatPadded(f fmt.State, verb rune, s string) {
+	layout := "%"
+	if f.Flag('-') {
+		layout += "-"
+	}
+	if w, ok := f.Width(); ok {
+		layout += strconv.Itoa(w)
+	}
+	if verb == 'q' {
+		layout += "q"
+	} else {
+		layout += "s"
+	}
+	fmt.Fprintf(f, layout, s)
+}
+
+// Format formats v using a layout with the placeholders
+//   - "{major}", "{minor}", "{patch}" for the numbers
+//   - "{prerelease}", "{build}" for prerelease and build meta data
+//   - "{-prerelease}", "{+build}" for prerelease and build meta data
+//     with a leading "-" and "+", if present
+//
+// Other text is kept, so "{major}_{minor}_{patch}" gives "1_2_3" and
+// "v{major}.{minor}.{patch}{-prerelease}" gives "v1.2.3-rc.1".
+func Format(v Version, layout string) string {
+	preParts := make([]string, 0, len(v.Pre))
+	for _, p := range v.Pre {
+		preParts = append(preParts, p.String())
+	}
+	pre := strings.Join(preParts, ".")
+	build := strings.Join(v.Build, ".")
+
+	prefixed := func(prefix, s string) string {
+		if len(s) == 0 {
+			return ""
+		}
+		return prefix + s
+	}
+	return strings.NewReplacer(
+		"{major}", strconv.FormatUint(v.Major, 10),
+		"{minor}", strconv.FormatUint(v.Minor, 10),
+		"{patch}", strconv.FormatUint(v.Patch, 10),
+		"{prerelease}", pre,
+		"{-prerelease}", prefixed("-", pre),
+		"{build}", build,
+		"{+build}", prefixed("+", build),
+	).Replace(layout)
+}
@@ -0,0 +1,67 @@
+package semver
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestVersionFormatter(t *testing.T) {
+	v := MustParse("1.2.3-rc.1+build.5")
+	tests := []struct {
+		layout string
+		v      Version
+		o      string
+	}{
+		{"%s", v, "1.2.3-rc.1+build.5"},
+		{"%v", v, "1.2.3-rc.1+build.5"},
+		{"%+v", v, "1.2.3-rc.1+build.5"},
+		{"%q", v, `"1.2.3-rc.1+build.5"`},
+		{"%#s", v, "v1.2.3-rc.1+build.5"},
+		{"%#v", v, `semver.Version{Major:0x1, Minor:0x2, Patch:0x3, Pre:[]semver.PRVersion{semver.PRVersion{VersionStr:"rc", VersionNum:0x0, IsNum:false}, semver.PRVersion{VersionStr:"", VersionNum:0x1, IsNum:true}}, Build:[]string{"build", "5"}}`},
+		{"%#v", Version{}, "semver.Version{Major:0x0, Minor:0x0, Patch:0x0, Pre:[]semver.PRVersion(nil), Build:[]string(nil)}"},
+		{"%.1s", v, "1"},
+		{"%.2s", v, "1.2"},
+		{"%.3s", v, "1.2.3"},
+		{"%.4s", v, "1.2.3-rc.1"},
+		{"%.5s", v, "1.2.3-rc.1"},
+		{"%.0s", v, ""},
+		{"%#.2s", v, "v1.2"},
+		{"%#.4q", v, `"v1.2.3-rc.1"`},
+		{"%8.3s|", v, "   1.2.3|"},
+		{"%-8.3s|", v, "1.2.3   |"},
+		{"%.4s", MustParse("1.2.3+build"), "1.2.3"},
+		{"%d", v, "%!d(semver.Version=1.2.3-rc.1+build.5)"},
+		{"%s", Version{}, "0.0.0"},
+	}
+	for _, test := range tests {
+		if o := fmt.Sprintf(test.layout, test.v); o != test.o {
+			t.Errorf("Formatting %q with %q, expected %q but got %q", test.v.String(), test.layout, test.o, o)
+		}
+	}
+
+	if o := fmt.Sprintf("%s", &v); o != "1.2.3-rc.1+build.5" {
+		t.Errorf("Formatting pointer, expected %q but got %q", "1.2.3-rc.1+build.5", o)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		v      string
+		layout string
+		o      string
+	}{
+		{"1.2.3-rc.1+build.5", "{major}_{minor}_{patch}", "1_2_3"},
+		{"1.2.3-rc.1+build.5", "v{major}.{minor}", "v1.2"},
+		{"1.2.3-rc.1+build.5", "{major}.{minor}.{patch}{-prerelease}", "1.2.3-rc.1"},
+		{"1.2.3-rc.1+build.5", "{prerelease}|{build}", "rc.1|build.5"},
+		{"1.2.3-rc.1+build.5", "{major}.{minor}.{patch}{-prerelease}{+build}", "1.2.3-rc.1+build.5"},
+		{"1.2.3", "{major}.{minor}.{patch}{-prerelease}{+build}", "1.2.3"},
+		{"1.2.3", "{prerelease}|{build}", "|"},
+		{"1.2.3", "release-{major}{unknown}", "release-1{unknown}"},
+	}
+	for _, test := range tests {
+		if o := Format(MustParse(test.v), test.layout); o != test.o {
+			t.Errorf("Formatting %q with %q, expected %q but got %q", test.v, test.layout, test.o, o)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package semver
+
+import (
+	"fmt"
+	"math"
+)
+
+// ReleaseType is the kind of release used by Inc.
+type ReleaseType string
+
+// Release types as used by node-semver.
+// ReleaseBuild and ReleaseNone are only returned by Diff.
+const (
+	ReleaseMajor      ReleaseType = "major"
+	ReleasePremajor   ReleaseType = "premajor"
+	ReleaseMinor      ReleaseType = "minor"
+	ReleasePreminor   ReleaseType = "preminor"
+	ReleasePatch      ReleaseType = "patch"
+	ReleasePrepatch   ReleaseType = "prepatch"
+	ReleasePrerelease ReleaseType = "prerelease"
+	ReleaseBuild      ReleaseType = "build"
+	ReleaseNone       ReleaseType = ""
+)
+
+// Inc increments v by the release type like node-semver's inc.
+// Build meta data is always dropped. If v could not be incremented,
+// an error is returned and v is left unchanged.
+//
+//   - "major", "minor" and "patch" bump the number and drop the prerelease.
+//     A prerelease of the resulting version is released instead of bumped:
+//     1.2.0-rc.1 with "minor" is 1.2.0, 1.2.1-rc.1 with "minor" is 1.3.0.
+//   - "premajor", "preminor" and "prepatch" bump the number and start a
+//     prerelease: 1.2.3 with "prepatch" is 1.2.4-0.
+//   - "prerelease" bumps the last numeric prerelease identifier, starting
+//     a "prepatch" for releases: 1.2.4-rc.0 is 1.2.4-rc.1, 1.2.3 is 1.2.4-0.
+//
+// For the pre types a non empty identifier is used as prerelease prefix,
+// a different prefix starts over at 0: 1.2.3 with "prerelease" and "rc" is
+// 1.2.4-rc.0, 1.2.4-beta.3 with "prerelease" and "rc" is 1.2.4-rc.0.
+// The identifier is ignored for the other release types.
+func (v *Version) Inc(release ReleaseType, identifier string) error {
+	var id PRVersion
+	if len(identifier) > 0 {
+		var err error
+		if id, err = NewPRVersion(identifier); err != nil {
+			return fmt.Errorf("Invalid prerelease identifier %q: %s", identifier, err)
+		}
+	}
+
+	n := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Pre: v.Pre}
+	switch release {
+	case ReleaseMajor:
+		if n.Minor != 0 || n.Patch != 0 || len(n.Pre) == 0 {
+			n.Major++
+		}
+		n.Minor, n.Patch, n.Pre = 0, 0, nil
+	case ReleaseMinor:
+		if n.Patch != 0 || len(n.Pre) == 0 {
+			n.Minor++
+		}
+		n.Patch, n.Pre = 0, nil
+	case ReleasePatch:
+		if len(n.Pre) == 0 {
+			n.Patch++
+		}
+		n.Pre = nil
+	case ReleasePremajor:
+		n.Major++
+		n.Minor, n.Patch = 0, 0
+		n.Pre = incPre(nil, identifier, id)
+	case ReleasePreminor:
+		n.Minor++
+		n.Patch = 0
+		n.Pre = incPre(nil, identifier, id)
+	case ReleasePrepatch:
+		n.Patch++
+		n.Pre = incPre(nil, identifier, id)
+	case ReleasePrerelease:
+		if len(n.Pre) == 0 {
+			n.Patch++
+		}
+		n.Pre = incPre(n.Pre, identifier, id)
+	default:
+		return fmt.Errorf("Unknown release type %q", release)
+	}
+	*v = n
+	return nil
+}
+
+// incPre bumps the last numeric identifier of a prerelease or appends 0
+// if there is none. A different prefix identifier starts over at 0.
+func incPre(pre []PRVersion, identifier string, id PRVersion) []PRVersion {
+	out := append([]PRVersion{}, pre...)
+	bumped := false
+	for i := len(out) - 1; i >= 0; i-- {
+		if out[i].IsNum {
+			out[i] = incNum(out[i])
+			bumped = true
+			break
+		}
+	}
+	if !bumped {
+		out = append(out, PRVersion{IsNum: true})
+	}
+
+	if len(identifier) == 0 {
+		return out
+	}
+	if out[0].Compare(id) != 0 || len(out) < 2 || !out[1].IsNum {
+		return []PRVersion{id, {IsNum: true}}
+	}
+	return out
+}
+
+// Change is the kind of change a release contains, used by Bump.
+type Change int
+
+// Changes ordered by impact.
+const (
+	ChangeFix Change = iota
+	ChangeFeature
+	ChangeBreaking
+)
+
+// BumpPolicy controls how Bump maps changes to release types.
+type BumpPolicy struct {
+	// BreakingAsMinor bumps the minor version for breaking changes while
+	// Major is 0, so the version stays in initial development.
+	BreakingAsMinor bool
+}
+
+// Bump increments v according to the change: a breaking change bumps the
+// major, a feature the minor and a fix the patch version.
+// Unlike IncrementMajor, IncrementMinor and IncrementPatch, 0.x versions
+// can be bumped. Prereleases are released like Inc does, so 1.0.0-rc.1
+// with a breaking change is 1.0.0.
+// If v could not be bumped, an error is returned and v is left unchanged.
+func (v *Version) Bump(c Change, p BumpPolicy) error {
+	switch c {
+	case ChangeBreaking:
+		if p.BreakingAsMinor && v.Major == 0 {
+			return v.Inc(ReleaseMinor, "")
+		}
+		return v.Inc(ReleaseMajor, "")
+	case ChangeFeature:
+		return v.Inc(ReleaseMinor, "")
+	case ChangeFix:
+		return v.Inc(ReleasePatch, "")
+	}
+	return fmt.Errorf("Unknown change %d", c)
+}
+
+// BumpMajor increments the major version, resetting minor and patch, like
+// Inc with "major". Prerelease and build meta data are dropped and
+// a prerelease of a major release is released: 2.0.0-rc.1 becomes 2.0.0.
+func (v *Version) BumpMajor() {
+	v.Inc(ReleaseMajor, "")
+}
+
+// BumpMinor increments the minor version, resetting patch, like Inc with
+// "minor". Prerelease and build meta data are dropped and a prerelease of
+// a minor release is released: 1.3.0-rc.1 becomes 1.3.0.
+func (v *Version) BumpMinor() {
+	v.Inc(ReleaseMinor, "")
+}
+
+// BumpPatch increments the patch version like Inc with "patch".
+// Prerelease and build meta data are dropped and a prerelease is
+// released: 1.2.3-rc.1+build.5 becomes 1.2.3.
+func (v *Version) BumpPatch() {
+	v.Inc(ReleasePatch, "")
+}
+
+// Finalize drops the prerelease and build meta data of v,
+// so 1.2.3-rc.1+build.5 becomes 1.2.3.
+func (v *Version) Finalize() {
+	v.Pre = nil
+	v.Build = nil
+}
+
+// incNum increments a numeric prerelease identifier, keeping it as digits
+// in VersionStr once it exceeds uint64.
+func incNum(pr PRVersion) PRVersion {
+	if len(pr.VersionStr) == 0 && pr.VersionNum < math.MaxUint64 {
+		pr.VersionNum++
+		return pr
+	}
+	digits := []byte(pr.String())
+	i := len(digits) - 1
+	for ; i >= 0 && digits[i] == '9'; i-- {
+		digits[i] = '0'
+	}
+	if i < 0 {
+		digits = append([]byte{'1'}, digits...)
+	} else {
+		digits[i]++
+	}
+	return PRVersion{VersionStr: string(digits), IsNum: true}
+}
@@ -0,0 +1,184 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestInc(t *testing.T) {
+	tests := []struct {
+		v          string
+		release    ReleaseType
+		identifier string
+		o          string
+	}{
+		{"1.2.3", ReleaseMajor, "", "2.0.0"},
+		{"1.2.3", ReleaseMinor, "", "1.3.0"},
+		{"1.2.3", ReleasePatch, "", "1.2.4"},
+		{"1.2.3-tag", ReleaseMajor, "", "2.0.0"},
+		{"1.0.0-1", ReleaseMajor, "", "1.0.0"},
+		{"1.2.0-1", ReleaseMajor, "", "2.0.0"},
+		{"1.2.0-5", ReleaseMinor, "", "1.2.0"},
+		{"1.2.1-5", ReleaseMinor, "", "1.3.0"},
+		{"1.2.3-5", ReleasePatch, "", "1.2.3"},
+		{"1.2.3+build.1", ReleasePatch, "", "1.2.4"},
+		{"0.1.2", ReleasePatch, "", "0.1.3"},
+		{"0.1.2", ReleaseMajor, "", "1.0.0"},
+		{"1.2.3", ReleasePremajor, "", "2.0.0-0"},
+		{"1.2.3", ReleasePreminor, "", "1.3.0-0"},
+		{"1.2.3", ReleasePrepatch, "", "1.2.4-0"},
+		{"1.2.3-rc.1", ReleasePrepatch, "", "1.2.4-0"},
+		{"1.2.3", ReleasePremajor, "rc", "2.0.0-rc.0"},
+		{"1.2.3", ReleasePreminor, "rc", "1.3.0-rc.0"},
+		{"1.2.3", ReleasePrepatch, "rc", "1.2.4-rc.0"},
+		{"1.2.3-rc.5", ReleasePremajor, "rc", "2.0.0-rc.0"},
+		{"1.2.3", ReleasePrerelease, "", "1.2.4-0"},
+		{"1.2.3-0", ReleasePrerelease, "", "1.2.3-1"},
+		{"1.2.3-alpha.0", ReleasePrerelease, "", "1.2.3-alpha.1"},
+		{"1.2.3-alpha.1.beta", ReleasePrerelease, "", "1.2.3-alpha.2.beta"},
+		{"1.2.3-alpha", ReleasePrerelease, "", "1.2.3-alpha.0"},
+		{"1.2.3", ReleasePrerelease, "rc", "1.2.4-rc.0"},
+		{"1.2.4-rc.0", ReleasePrerelease, "rc", "1.2.4-rc.1"},
+		{"1.2.4-beta.3", ReleasePrerelease, "rc", "1.2.4-rc.0"},
+		{"1.2.4-rc", ReleasePrerelease, "rc", "1.2.4-rc.0"},
+		{"1.2.4-rc.beta", ReleasePrerelease, "rc", "1.2.4-rc.0"},
+		{"1.2.4-1", ReleasePrerelease, "rc", "1.2.4-rc.0"},
+		{"1.2.4-rc.0+build", ReleasePrerelease, "", "1.2.4-rc.1"},
+		// Errors
+		{"1.2.3", ReleaseType("build"), "", ""},
+		{"1.2.3", ReleasePrerelease, "r@c", ""},
+		{"1.2.3", ReleasePrerelease, "01", ""},
+	}
+
+	for _, tc := range tests {
+		v := MustParse(tc.v)
+		err := v.Inc(tc.release, tc.identifier)
+		if err != nil {
+			if tc.o != "" {
+				t.Errorf("Invalid for case %q %s %q: Expected %q, got error %q", tc.v, tc.release, tc.identifier, tc.o, err)
+			} else if v.NE(MustParse(tc.v)) {
+				t.Errorf("Invalid for case %q %s %q: Expected unchanged version, got %q", tc.v, tc.release, tc.identifier, v)
+			}
+			continue
+		}
+		if tc.o == "" {
+			t.Errorf("Invalid for case %q %s %q: Expected error, got %q", tc.v, tc.release, tc.identifier, v)
+		} else if v.String() != tc.o {
+			t.Errorf("Invalid for case %q %s %q: Expected %q, got: %q", tc.v, tc.release, tc.identifier, tc.o, v)
+		}
+	}
+}
+
+func TestIncDoesNotShareSlices(t *testing.T) {
+	v := MustParse("1.2.3-alpha.1")
+	o := v
+	if err := o.Inc(ReleasePrerelease, ""); err != nil {
+		t.Fatalf("Unexpected error %q", err)
+	}
+	if v.String() != "1.2.3-alpha.1" {
+		t.Errorf("Expected original version to be unchanged, got %q", v)
+	}
+}
+
+func TestBump(t *testing.T) {
+	tests := []struct {
+		v      string
+		change Change
+		policy BumpPolicy
+		o      string
+	}{
+		{"1.2.3", ChangeBreaking, BumpPolicy{}, "2.0.0"},
+		{"1.2.3", ChangeFeature, BumpPolicy{}, "1.3.0"},
+		{"1.2.3", ChangeFix, BumpPolicy{}, "1.2.4"},
+		{"0.1.2", ChangeBreaking, BumpPolicy{}, "1.0.0"},
+		{"0.1.2", ChangeFeature, BumpPolicy{}, "0.2.0"},
+		{"0.1.2", ChangeFix, BumpPolicy{}, "0.1.3"},
+		{"0.0.0", ChangeFix, BumpPolicy{}, "0.0.1"},
+		{"0.1.2", ChangeBreaking, BumpPolicy{BreakingAsMinor: true}, "0.2.0"},
+		{"0.1.2", ChangeFeature, BumpPolicy{BreakingAsMinor: true}, "0.2.0"},
+		{"0.1.2", ChangeFix, BumpPolicy{BreakingAsMinor: true}, "0.1.3"},
+		{"1.2.3", ChangeBreaking, BumpPolicy{BreakingAsMinor: true}, "2.0.0"},
+		{"1.0.0-rc.1", ChangeBreaking, BumpPolicy{}, "1.0.0"},
+		{"0.2.0-rc.1", ChangeBreaking, BumpPolicy{BreakingAsMinor: true}, "0.2.0"},
+		// Errors
+		{"1.2.3", Change(42), BumpPolicy{}, ""},
+	}
+
+	for _, tc := range tests {
+		v := MustParse(tc.v)
+		err := v.Bump(tc.change, tc.policy)
+		if err != nil {
+			if tc.o != "" {
+				t.Errorf("Invalid for case %q %d %+v: Expected %q, got error %q", tc.v, tc.change, tc.policy, tc.o, err)
+			}
+			continue
+		}
+		if tc.o == "" {
+			t.Errorf("Invalid for case %q %d %+v: Expected error, got %q", tc.v, tc.change, tc.policy, v)
+		} else if v.String() != tc.o {
+			t.Errorf("Invalid for case %q %d %+v: Expected %q, got: %q", tc.v, tc.change, tc.policy, tc.o, v)
+		}
+	}
+}
+
+func TestBumpRelease(t *testing.T) {
+	tests := []struct {
+		v     string
+		major string
+		minor string
+		patch string
+		final string
+	}{
+		{"1.2.3", "2.0.0", "1.3.0", "1.2.4", "1.2.3"},
+		{"1.2.3-rc.1+build.5", "2.0.0", "1.3.0", "1.2.3", "1.2.3"},
+		{"1.2.3+build.5", "2.0.0", "1.3.0", "1.2.4", "1.2.3"},
+		{"1.2.0-rc.1", "2.0.0", "1.2.0", "1.2.0", "1.2.0"},
+		{"2.0.0-rc.1", "2.0.0", "2.0.0", "2.0.0", "2.0.0"},
+		{"0.1.2", "1.0.0", "0.2.0", "0.1.3", "0.1.2"},
+	}
+
+	for _, tc := range tests {
+		v := MustParse(tc.v)
+		v.BumpMajor()
+		if v.String() != tc.major {
+			t.Errorf("Invalid BumpMajor for case %q: Expected %q, got: %q", tc.v, tc.major, v)
+		}
+		v = MustParse(tc.v)
+		v.BumpMinor()
+		if v.String() != tc.minor {
+			t.Errorf("Invalid BumpMinor for case %q: Expected %q, got: %q", tc.v, tc.minor, v)
+		}
+		v = MustParse(tc.v)
+		v.BumpPatch()
+		if v.String() != tc.patch {
+			t.Errorf("Invalid BumpPatch for case %q: Expected %q, got: %q", tc.v, tc.patch, v)
+		}
+		v = MustParse(tc.v)
+		v.Finalize()
+		if v.String() != tc.final {
+			t.Errorf("Invalid Finalize for case %q: Expected %q, got: %q", tc.v, tc.final, v)
+		}
+	}
+}
+
+func TestIncBigPrereleaseNumbers(t *testing.T) {
+	tests := []struct {
+		v string
+		o string
+	}{
+		{"1.0.0-18446744073709551615", "1.0.0-18446744073709551616"},
+		{"1.0.0-rc.20231012153000123456789", "1.0.0-rc.20231012153000123456790"},
+		{"1.0.0-99999999999999999999", "1.0.0-100000000000000000000"},
+	}
+	for _, tc := range tests {
+		v, err := ParseWithOptions(tc.v, ParseOptions{AllowBigPrereleaseNumbers: true})
+		if err != nil {
+			t.Errorf("Error parsing %q: %q", tc.v, err)
+			continue
+		}
+		if err := v.Inc(ReleasePrerelease, ""); err != nil {
+			t.Errorf("Unexpected error for %q: %q", tc.v, err)
+		} else if v.String() != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.v, tc.o, v)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MultiVersion is a version with any number of numeric components,
+// like the Windows file version "10.0.19041.1" or the Chromium version
+// "120.0.6099.109". Prerelease and build meta data follow the semver rules.
+type MultiVersion struct {
+	Components []uint64
+	Pre        []PRVersion
+	Build      []string //No Precedence
+}
+
+// ParseMulti parses a version string with one or more numeric components
+// like "1.2.3.4-rc.1+build.5" and returns a validated MultiVersion or error.
+// Like Parse, a leading "v" is stripped. The error is a *ParseError.
+func ParseMulti(s string) (MultiVersion, error) {
+	if len(s) == 0 {
+		return MultiVersion{}, newParseError(s, "", 0, ErrEmpty, "Version string empty")
+	}
+	input := s
+
+	offset := 0
+	if strings.HasPrefix(s, "v") {
+		s = s[1:]
+		offset = 1
+	}
+
+	core, pre, build := s, "", ""
+	hasPre, hasBuild := false, false
+	if i := strings.IndexRune(core, '+'); i != -1 {
+		core, build, hasBuild = core[:i], core[i+1:], true
+	}
+	if i := strings.IndexRune(core, '-'); i != -1 {
+		core, pre, hasPre = core[:i], core[i+1:], true
+	}
+
+	v := MultiVersion{}
+	for i, c := range strings.Split(core, ".") {
+		component := componentName(i)
+		if !containsOnly(c, numbers) {
+			return MultiVersion{}, newParseError(input, component, offset, ErrInvalidChar, fmt.Sprintf("Invalid character(s) found in %s number %q", component, c))
+		}
+		if hasLeadingZeroes(c) {
+			return MultiVersion{}, newParseError(input, component, offset, ErrLeadingZero, fmt.Sprintf("%s number must not contain leading zeroes %q", strings.ToUpper(component[:1])+component[1:], c))
+		}
+		n, err := parseNumber(input, component, offset, c)
+		if err != nil {
+			return MultiVersion{}, err
+		}
+		v.Components = append(v.Components, n)
+		offset += len(c) + 1
+	}
+
+	if hasPre {
+		for _, prstr := range strings.Split(pre, ".") {
+			parsedPR, err := NewPRVersion(prstr)
+			if err != nil {
+				return MultiVersion{}, relocateParseError(err, input, offset)
+			}
+			v.Pre = append(v.Pre, parsedPR)
+			offset += len(prstr) + 1
+		}
+	}
+
+	if hasBuild {
+		for _, str := range strings.Split(build, ".") {
+			if _, err := NewBuildVersion(str); err != nil {
+				return MultiVersion{}, relocateParseError(err, input, offset)
+			}
+			v.Build = append(v.Build, str)
+			offset += len(str) + 1
+		}
+	}
+
+	return v, nil
+}
+
+// MustParseMulti is like ParseMulti but panics if the version cannot be parsed.
+func MustParseMulti(s string) MultiVersion {
+	v, err := ParseMulti(s)
+	if err != nil {
+		panic(`semver: ParseMulti(` + s + `): ` + err.Error())
+	}
+	return v
+}
+
+// componentName returns the name of the i-th numeric component.
+func componentName(i int) string {
+	switch i {
+	case 0:
+		return "major"
+	case 1:
+		return "minor"
+	case 2:
+		return "patch"
+	}
+	return "component " + strconv.Itoa(i+1)
+}
+
+// MultiVersion to string
+func (v MultiVersion) String() string {
+	b := make([]byte, 0, 8)
+	for i, c := range v.Components {
+		if i > 0 {
+			b = append(b, '.')
+		}
+		b = strconv.AppendUint(b, c, 10)
+	}
+
+	if len(v.Pre) > 0 {
+		b = append(b, '-')
+		b = append(b, v.Pre[0].String()...)
+
+		for _, pre := range v.Pre[1:] {
+			b = append(b, '.')
+			b = append(b, pre.String()...)
+		}
+	}
+
+	if len(v.Build) > 0 {
+		b = append(b, '+')
+		b = append(b, v.Build[0]...)
+
+		for _, build := range v.Build[1:] {
+			b = append(b, '.')
+			b = append(b, build...)
+		}
+	}
+
+	return string(b)
+}
+
+// Compare compares MultiVersions v to o:
+// -1 == v is less than o
+// 0 == v is equal to o
+// 1 == v is greater than o
+//
+// Missing components are 0, so "1.2" equals "1.2.0.0".
+func (v MultiVersion) Compare(o MultiVersion) int {
+	for i := 0; i < len(v.Components) || i < len(o.Components); i++ {
+		var a, b uint64
+		if i < len(v.Components) {
+			a = v.Components[i]
+		}
+		if i < len(o.Components) {
+			b = o.Components[i]
+		}
+		if a != b {
+			if a > b {
+				return 1
+			}
+			return -1
+		}
+	}
+	return comparePre(v.Pre, o.Pre)
+}
+
+// EQ checks if v is equal to o.
+func (v MultiVersion) EQ(o MultiVersion) bool {
+	return (v.Compare(o) == 0)
+}
+
+// NE checks if v is not equal to o.
+func (v MultiVersion) NE(o MultiVersion) bool {
+	return (v.Compare(o) != 0)
+}
+
+// GT checks if v is greater than o.
+func (v MultiVersion) GT(o MultiVersion) bool {
+	return (v.Compare(o) == 1)
+}
+
+// GTE checks if v is greater than or equal to o.
+func (v MultiVersion) GTE(o MultiVersion) bool {
+	return (v.Compare(o) >= 0)
+}
+
+// LT checks if v is less than o.
+func (v MultiVersion) LT(o MultiVersion) bool {
+	return (v.Compare(o) == -1)
+}
+
+// LTE checks if v is less than or equal to o.
+func (v MultiVersion) LTE(o MultiVersion) bool {
+	return (v.Compare(o) <= 0)
+}
+
+// MultiVersions represents multiple MultiVersions.
+type MultiVersions []MultiVersion
+
+// Len returns length of version collection
+func (s MultiVersions) Len() int {
+	return len(s)
+}
+
+// Swap swaps two versions inside the collection by its indices
+func (s MultiVersions) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less checks if version at index i is less than version at index j
+func (s MultiVersions) Less(i, j int) bool {
+	return s[i].LT(s[j])
+}
+
+// SortMulti sorts a slice of MultiVersions
+func SortMulti(versions []MultiVersion) {
+	sort.Sort(MultiVersions(versions))
+}
+
+// MultiRange represents a range of MultiVersions.
+// A given MultiVersion is tested against the range like a Range.
+type MultiRange func(MultiVersion) bool
+
+// OR combines the existing MultiRange with another MultiRange using logical OR.
+func (rf MultiRange) OR(f MultiRange) MultiRange {
+	return MultiRange(func(v MultiVersion) bool {
+		return rf(v) || f(v)
+	})
+}
+
+// AND combines the existing MultiRange with another MultiRange using logical AND.
+func (rf MultiRange) AND(f MultiRange) MultiRange {
+	return MultiRange(func(v MultiVersion) bool {
+		return rf(v) && f(v)
+	})
+}
+
+// ParseMultiRange parses a range of MultiVersions and returns a MultiRange.
+// If the range could not be parsed an error is returned.
+//
+// Ranges consist of the comparators "<", "<=", ">", ">=", "=" and "!="
+// followed by a version, a version without comparator means "=":
+//   - ">=1.2.3.4 <1.3", ">= 1.2.3.4 < 1.3"
+//   - "10.0.19041.1 || >=10.0.22000"
+//
+// Comparators separated by space are linked by logical AND, "||" links
+// them by logical OR. Tilde, caret and x-ranges are not supported.
+func ParseMultiRange(s string) (MultiRange, error) {
+	var orFn MultiRange
+	for _, part := range strings.Split(s, "||") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("Could not parse MultiRange %q: Empty set", s)
+		}
+		var andFn MultiRange
+		for i := 0; i < len(fields); i++ {
+			f := fields[i]
+			// `>= 1.2.3.4` => `>=1.2.3.4`
+			if strings.Trim(f, "<>=!") == "" && i+1 < len(fields) {
+				i++
+				f += fields[i]
+			}
+			rf, err := parseMultiComparator(f)
+			if err != nil {
+				return nil, fmt.Errorf("Could not parse MultiRange %q: %s", s, err)
+			}
+			if andFn == nil {
+				andFn = rf
+			} else {
+				andFn = andFn.AND(rf)
+			}
+		}
+		if orFn == nil {
+			orFn = andFn
+		} else {
+			orFn = orFn.OR(andFn)
+		}
+	}
+	return orFn, nil
+}
+
+// parseMultiComparator parses a single comparator like ">=1.2.3.4".
+func parseMultiComparator(s string) (MultiRange, error) {
+	opStr, vStr, err := splitComparatorVersion(s)
+	if err != nil {
+		return nil, err
+	}
+	v, err := ParseMulti(vStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var accept func(comp int) bool
+	switch normalizeOperator(opStr) {
+	case "=":
+		accept = func(comp int) bool { return comp == 0 }
+	case "!=":
+		accept = func(comp int) bool { return comp != 0 }
+	case ">":
+		accept = func(comp int) bool { return comp > 0 }
+	case ">=":
+		accept = func(comp int) bool { return comp >= 0 }
+	case "<":
+		accept = func(comp int) bool { return comp < 0 }
+	case "<=":
+		accept = func(comp int) bool { return comp <= 0 }
+	default:
+		return nil, fmt.Errorf("Could not parse comparator %q in %q", opStr, s)
+	}
+	return func(o MultiVersion) bool {
+		return accept(o.Compare(v))
+	}, nil
+}
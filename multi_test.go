@@ -0,0 +1,171 @@
+package semver
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMulti(t *testing.T) {
+	tests := []struct {
+		s string
+		v MultiVersion
+	}{
+		{"1", MultiVersion{[]uint64{1}, nil, nil}},
+		{"1.2.3", MultiVersion{[]uint64{1, 2, 3}, nil, nil}},
+		{"10.0.19041.1", MultiVersion{[]uint64{10, 0, 19041, 1}, nil, nil}},
+		{"v1.2.3.4.5", MultiVersion{[]uint64{1, 2, 3, 4, 5}, nil, nil}},
+		{"1.2.3.4-rc.1+build.5", MultiVersion{[]uint64{1, 2, 3, 4}, []PRVersion{prstr("rc"), prnum(1)}, []string{"build", "5"}}},
+		{"1.2.3.4+build-1", MultiVersion{[]uint64{1, 2, 3, 4}, nil, []string{"build-1"}}},
+	}
+	for _, test := range tests {
+		v, err := ParseMulti(test.s)
+		if err != nil {
+			t.Errorf("Error parsing %q: %q", test.s, err)
+			continue
+		}
+		if !reflect.DeepEqual(v, test.v) {
+			t.Errorf("Parsing %q, expected %+v but got %+v", test.s, test.v, v)
+		}
+		if s := v.String(); s != strings.TrimPrefix(test.s, "v") {
+			t.Errorf("Parsing %q, expected string %q but got %q", test.s, strings.TrimPrefix(test.s, "v"), s)
+		}
+	}
+}
+
+func TestParseMultiErrors(t *testing.T) {
+	tests := []struct {
+		s         string
+		err       error
+		component string
+		offset    int
+	}{
+		{"", ErrEmpty, "", 0},
+		{"1..3", ErrEmpty, "minor", 2},
+		{"1.2.3.04", ErrLeadingZero, "component 4", 6},
+		{"1.2.3.x", ErrInvalidChar, "component 4", 6},
+		{"1.2.3.99999999999999999999", ErrOverflow, "component 4", 6},
+		{"1.2.3.4-rc.01", ErrLeadingZero, "prerelease", 11},
+		{"1.2.3.4-", ErrEmpty, "prerelease", 8},
+		{"1.2.3.4+b..1", ErrEmpty, "build", 10},
+		{"1.2.3.4+b$", ErrInvalidChar, "build", 8},
+	}
+	for _, test := range tests {
+		_, err := ParseMulti(test.s)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("Parsing %q, expected ParseError but got %v", test.s, err)
+			continue
+		}
+		if !errors.Is(err, test.err) || pe.Component != test.component || pe.Offset != test.offset || pe.Input != test.s {
+			t.Errorf("Parsing %q, expected %q in %q at %d but got %q in %q at %d", test.s, test.err, test.component, test.offset, pe.Err, pe.Component, pe.Offset)
+		}
+	}
+}
+
+func TestMultiVersionCompare(t *testing.T) {
+	ordered := []string{
+		"1.2.3-alpha",
+		"1.2.3-alpha.1",
+		"1.2.3",
+		"1.2.3.1-rc.1",
+		"1.2.3.1",
+		"1.2.3.2",
+		"1.2.3.10",
+		"1.2.4",
+		"1.10",
+	}
+	for i := 1; i < len(ordered); i++ {
+		a, b := MustParseMulti(ordered[i-1]), MustParseMulti(ordered[i])
+		if !a.LT(b) || !a.LTE(b) || !b.GT(a) || !b.GTE(a) || !a.NE(b) {
+			t.Errorf("%q should be less than %q", a, b)
+		}
+	}
+	equal := [][2]string{
+		{"1.2", "1.2.0.0"},
+		{"1.2.3.0", "1.2.3"},
+		{"1.2.3.0-rc.1+a", "1.2.3-rc.1+b"},
+	}
+	for _, e := range equal {
+		if a, b := MustParseMulti(e[0]), MustParseMulti(e[1]); !a.EQ(b) || a.Compare(b) != 0 {
+			t.Errorf("%q should be equal to %q", a, b)
+		}
+	}
+}
+
+func TestSortMulti(t *testing.T) {
+	v := []MultiVersion{
+		MustParseMulti("1.2.3.10"),
+		MustParseMulti("1.2.3"),
+		MustParseMulti("1.2.3.2"),
+		MustParseMulti("1.0.0.0-rc"),
+	}
+	SortMulti(v)
+	expected := []string{"1.0.0.0-rc", "1.2.3", "1.2.3.2", "1.2.3.10"}
+	for i, e := range expected {
+		if v[i].String() != e {
+			t.Errorf("Sorted %d, expected %q but got %q", i, e, v[i])
+		}
+	}
+}
+
+func TestParseMultiRange(t *testing.T) {
+	type tv struct {
+		v string
+		b bool
+	}
+	tests := []struct {
+		i string
+		t []tv
+	}{
+		{">=1.2.3.4 <1.3", []tv{
+			{"1.2.3.3", false},
+			{"1.2.3.4", true},
+			{"1.2.99.1", true},
+			{"1.3.0.0", false},
+		}},
+		{"10.0.19041.1 || >=10.0.22000", []tv{
+			{"10.0.19041.1", true},
+			{"10.0.19041.2", false},
+			{"10.0.22000.1", true},
+		}},
+		{"!=1.2.3.4", []tv{
+			{"1.2.3.4", false},
+			{"1.2.3.5", true},
+		}},
+		{">= 1.2.3.4 < 1.3", []tv{
+			{"1.2.3.3", false},
+			{"1.2.3.4", true},
+			{"1.3.0.0", false},
+		}},
+		{"!= 1.2.3.4 || = 2", []tv{
+			{"1.2.3.4", false},
+			{"1.2.3.5", true},
+			{"2.0.0.0", true},
+		}},
+		{"=1.2 >0.1 <=2 >=1.2", []tv{
+			{"1.2.0.0", true},
+			{"1.2.0.1", false},
+		}},
+	}
+
+	for _, tc := range tests {
+		r, err := ParseMultiRange(tc.i)
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		for _, tvc := range tc.t {
+			if res := r(MustParseMulti(tvc.v)); res != tvc.b {
+				t.Errorf("Invalid for case %q matching %q: Expected %t, got: %t", tc.i, tvc.v, tvc.b, res)
+			}
+		}
+	}
+
+	for _, s := range []string{"", ">=1.2.3.4 ||", ">=1.2.x", "~1.2.3.4", "=>1.2", ">= 1.2 <", ">= >= 1.2"} {
+		if _, err := ParseMultiRange(s); err == nil {
+			t.Errorf("Expected error for range %q, got none", s)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// tolerantParseOptions accept versions like ParseTolerant does.
+var tolerantParseOptions = ParseOptions{
+	AllowPrefix:       true,
+	AllowShort:        true,
+	AllowLeadingZeros: true,
+	AllowWhitespace:   true,
+}
+
+// originalParseOptions accept every spelling ParseOriginalWithOptions
+// can produce, to check whether the original string still matches.
+var originalParseOptions = ParseOptions{
+	AllowPrefix:                  true,
+	AllowShort:                   true,
+	AllowLeadingZeros:            true,
+	AllowMissingPrereleaseHyphen: true,
+	AllowWhitespace:              true,
+	AllowBigPrereleaseNumbers:    true,
+}
+
+// OriginalVersion is a Version remembering the string it was parsed from,
+// so tags like "v1.2" can be displayed and written back as found while
+// comparing semantically: a.Compare(b.Version).
+// Once the Version is changed, e.g. by BumpPatch, the original string is
+// outdated and the Version with the original prefix is used instead.
+type OriginalVersion struct {
+	Version
+	// Original is the string the version was parsed from.
+	Original string
+	// Prefix is the prefix of the original string, like "v".
+	Prefix string
+}
+
+// ParseOriginal parses a version string as leniently as ParseTolerant,
+// accepting a "v" or "V" prefix, short versions and leading zeros,
+// and keeps the original string.
+func ParseOriginal(s string) (OriginalVersion, error) {
+	return ParseOriginalWithOptions(s, tolerantParseOptions)
+}
+
+// ParseOriginalWithOptions parses a version string like ParseWithOptions
+// and keeps the original string.
+func ParseOriginalWithOptions(s string, opts ParseOptions) (OriginalVersion, error) {
+	v, err := ParseWithOptions(s, opts)
+	if err != nil {
+		return OriginalVersion{}, err
+	}
+	ov := OriginalVersion{Version: v, Original: s}
+	if trimmed := strings.TrimSpace(s); len(trimmed) > 0 && (trimmed[0] == 'v' || trimmed[0] == 'V') {
+		ov.Prefix = trimmed[:1]
+	}
+	return ov, nil
+}
+
+// String returns the original string while it still represents the version,
+// otherwise the version with the original prefix like "v1.2.1".
+func (ov OriginalVersion) String() string {
+	if len(ov.Original) > 0 {
+		if v, err := ParseWithOptions(ov.Original, originalParseOptions); err == nil && v.EqualExact(ov.Version) {
+			return ov.Original
+		}
+	}
+	return ov.Prefix + ov.Version.String()
+}
+
+// Format implements the fmt.Formatter interface.
+// The verbs %s, %v and %q print the version like String, so the original
+// string is kept. The '#' flag and the precision select the parts of the
+// Version like Version.Format does, %#v prints the Go syntax representation.
+func (ov OriginalVersion) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprintf(f, "semver.OriginalVersion{Version:%#v, Original:%#v, Prefix:%#v}",
+				ov.Version, ov.Original, ov.Prefix)
+			return
+		}
+	case 's', 'q':
+	default:
+		fmt.Fprintf(f, "%%!%c(semver.OriginalVersion=%s)", verb, ov.String())
+		return
+	}
+
+	if _, ok := f.Precision(); ok || f.Flag('#') {
+		ov.Version.Format(f, verb)
+		return
+	}
+	formatPadded(f, verb, ov.String())
+}
+
+// MarshalJSON implements the encoding/json.Marshaler interface
+// using String.
+func (ov OriginalVersion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ov.String())
+}
+
+// UnmarshalJSON implements the encoding/json.Unmarshaler interface
+// parsing like ParseOriginal.
+func (ov *OriginalVersion) UnmarshalJSON(data []byte) (err error) {
+	var versionString string
+
+	if err = json.Unmarshal(data, &versionString); err != nil {
+		return
+	}
+
+	*ov, err = ParseOriginal(versionString)
+
+	return
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+// using String.
+func (ov OriginalVersion) MarshalText() ([]byte, error) {
+	return []byte(ov.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+// parsing like ParseOriginal.
+func (ov *OriginalVersion) UnmarshalText(data []byte) (err error) {
+	*ov, err = ParseOriginal(string(data))
+
+	return
+}
+
+// MarshalXMLAttr implements the encoding/xml.MarshalerAttr interface
+// using String.
+func (ov OriginalVersion) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: ov.String()}, nil
+}
+
+// UnmarshalXMLAttr implements the encoding/xml.UnmarshalerAttr interface
+// parsing like ParseOriginal.
+func (ov *OriginalVersion) UnmarshalXMLAttr(attr xml.Attr) (err error) {
+	*ov, err = ParseOriginal(attr.Value)
+
+	return
+}
+
+// Scan implements the database/sql.Scanner interface
+// parsing like ParseOriginal.
+func (ov *OriginalVersion) Scan(src interface{}) error {
+	var str string
+	switch src := src.(type) {
+	case string:
+		str = src
+	case []byte:
+		str = string(src)
+	default:
+		return fmt.Errorf("version.Scan: cannot convert %T to string", src)
+	}
+
+	t, err := ParseOriginal(str)
+	if err != nil {
+		return err
+	}
+	*ov = t
+	return nil
+}
+
+// Value implements the database/sql/driver.Valuer interface
+// using String.
+func (ov OriginalVersion) Value() (driver.Value, error) {
+	return ov.String(), nil
+}
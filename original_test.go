@@ -0,0 +1,210 @@
+package semver
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"testing"
+)
+
+func TestParseOriginal(t *testing.T) {
+	tests := []struct {
+		s       string
+		version string
+		prefix  string
+	}{
+		{"1.2.3", "1.2.3", ""},
+		{"v1.2", "1.2.0", "v"},
+		{"V1.2.3-rc.1", "1.2.3-rc.1", "V"},
+		{"v01.02.3", "1.2.3", "v"},
+		{" v1 ", "1.0.0", "v"},
+		// Errors
+		{"release-1.2", "", ""},
+		{"1.2-rc.1", "", ""},
+	}
+
+	for _, test := range tests {
+		ov, err := ParseOriginal(test.s)
+		if err != nil {
+			if test.version != "" {
+				t.Errorf("Error parsing %q: %q", test.s, err)
+			}
+			continue
+		}
+		if test.version == "" {
+			t.Errorf("Parsing %q, expected error but got %q", test.s, ov)
+			continue
+		}
+		if ov.Version.String() != test.version {
+			t.Errorf("Parsing %q, expected version %q but got %q", test.s, test.version, ov.Version)
+		}
+		if ov.String() != test.s || ov.Original != test.s {
+			t.Errorf("Parsing %q, expected original spelling but got %q", test.s, ov)
+		}
+		if ov.Prefix != test.prefix {
+			t.Errorf("Parsing %q, expected prefix %q but got %q", test.s, test.prefix, ov.Prefix)
+		}
+	}
+}
+
+func TestParseOriginalWithOptions(t *testing.T) {
+	if _, err := ParseOriginalWithOptions("v1.2.3", ParseOptions{}); err == nil {
+		t.Errorf("Expected error for prefix, got none")
+	}
+	ov, err := ParseOriginalWithOptions("1.2.3rc.1", ParseOptions{AllowMissingPrereleaseHyphen: true})
+	if err != nil {
+		t.Fatalf("Unexpected error %q", err)
+	}
+	if ov.Version.String() != "1.2.3-rc.1" || ov.String() != "1.2.3rc.1" {
+		t.Errorf("Unexpected version %q, original %q", ov.Version, ov)
+	}
+}
+
+func TestOriginalVersionCompare(t *testing.T) {
+	a, b := mustParseOriginal("v1.2"), mustParseOriginal("1.2.0")
+	if !a.EQ(b.Version) {
+		t.Errorf("%q should be equal to %q", a, b)
+	}
+	if c := mustParseOriginal("v1.10"); !c.GT(a.Version) {
+		t.Errorf("%q should be greater than %q", c, a)
+	}
+	if (OriginalVersion{Version: MustParse("1.2.3")}).String() != "1.2.3" {
+		t.Errorf("Expected version string without original")
+	}
+}
+
+func TestOriginalVersionJSON(t *testing.T) {
+	ov := mustParseOriginal("v1.2")
+	data, err := json.Marshal(ov)
+	if err != nil {
+		t.Fatalf("Unexpected error %q", err)
+	}
+	if string(data) != `"v1.2"` {
+		t.Errorf("Expected %q, got %q", `"v1.2"`, data)
+	}
+
+	var o OriginalVersion
+	if err := json.Unmarshal(data, &o); err != nil {
+		t.Fatalf("Unexpected error %q", err)
+	}
+	if o.Original != "v1.2" || o.Prefix != "v" || !o.EQ(ov.Version) {
+		t.Errorf("Expected %q, got %+v", ov, o)
+	}
+	if err := json.Unmarshal([]byte(`"foo"`), &o); err == nil {
+		t.Errorf("Expected error, got none")
+	}
+}
+
+func TestOriginalVersionModified(t *testing.T) {
+	tests := []struct {
+		s      string
+		modify func(ov *OriginalVersion) error
+		o      string
+	}{
+		{"v1.2", func(ov *OriginalVersion) error { ov.BumpPatch(); return nil }, "v1.2.1"},
+		{"V1.2.3-rc.1", func(ov *OriginalVersion) error { ov.Finalize(); return nil }, "V1.2.3"},
+		{"1.2", func(ov *OriginalVersion) error { return ov.Inc(ReleaseMinor, "") }, "1.3.0"},
+		{"v1.2", func(ov *OriginalVersion) error { return ov.IncrementMajor() }, "v2.0.0"},
+		{"v1.2", func(ov *OriginalVersion) error { ov.Build = []string{"b1"}; return nil }, "v1.2.0+b1"},
+		{"v1.2", func(ov *OriginalVersion) error { return nil }, "v1.2"},
+	}
+	for _, test := range tests {
+		ov := mustParseOriginal(test.s)
+		if err := test.modify(&ov); err != nil {
+			t.Fatalf("Unexpected error %q", err)
+		}
+		if ov.String() != test.o {
+			t.Errorf("Modifying %q, expected %q but got %q", test.s, test.o, ov.String())
+		}
+		data, err := json.Marshal(ov)
+		if err != nil || string(data) != `"`+test.o+`"` {
+			t.Errorf("Modifying %q, expected JSON %q but got %s (%v)", test.s, test.o, data, err)
+		}
+		if val, err := ov.Value(); err != nil || val != test.o {
+			t.Errorf("Modifying %q, expected value %q but got %q (%v)", test.s, test.o, val, err)
+		}
+	}
+}
+
+func TestOriginalVersionSQL(t *testing.T) {
+	var ov OriginalVersion
+	if err := ov.Scan([]byte("v2.1")); err != nil {
+		t.Fatalf("Unexpected error %q", err)
+	}
+	if val, err := ov.Value(); err != nil || val != "v2.1" {
+		t.Errorf("Expected %q, got %q (%v)", "v2.1", val, err)
+	}
+	if err := ov.Scan(42); err == nil {
+		t.Errorf("Expected error, got none")
+	}
+	if err := ov.Scan("foo"); err == nil {
+		t.Errorf("Expected error, got none")
+	}
+}
+
+func mustParseOriginal(s string) OriginalVersion {
+	ov, err := ParseOriginal(s)
+	if err != nil {
+		panic(err)
+	}
+	return ov
+}
+
+func TestOriginalVersionText(t *testing.T) {
+	type config struct {
+		XMLName xml.Name        `xml:"config"`
+		Attr    OriginalVersion `xml:"version,attr"`
+		Elem    OriginalVersion `xml:"min"`
+	}
+	in := config{Attr: mustParseOriginal("v1.2"), Elem: mustParseOriginal("2")}
+	b, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<config version="v1.2"><min>2</min></config>`
+	if string(b) != expected {
+		t.Fatalf("Expected %s, got %s", expected, string(b))
+	}
+
+	var out config
+	if err := xml.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Attr.Original != "v1.2" || !out.Attr.Version.EQ(Version{1, 2, 0, nil, nil}) ||
+		out.Elem.Original != "2" || !out.Elem.Version.EQ(Version{2, 0, 0, nil, nil}) {
+		t.Fatalf("Unexpected result %+v", out)
+	}
+	if err := xml.Unmarshal([]byte(`<config version="a.b"></config>`), &out); err == nil {
+		t.Fatal("Expected error for invalid attribute")
+	}
+}
+
+func TestOriginalVersionFormat(t *testing.T) {
+	ov := mustParseOriginal("v1.2")
+	tests := []struct {
+		layout string
+		o      string
+	}{
+		{"%s", "v1.2"},
+		{"%v", "v1.2"},
+		{"%q", `"v1.2"`},
+		{"%6s|", "  v1.2|"},
+		{"%-6s|", "v1.2  |"},
+		{"%.1s", "1"},
+		{"%#.2s", "v1.2"},
+		{"%#s", "v1.2.0"},
+		{"%d", "%!d(semver.OriginalVersion=v1.2)"},
+		{"%#v", `semver.OriginalVersion{Version:semver.Version{Major:0x1, Minor:0x2, Patch:0x0, Pre:[]semver.PRVersion(nil), Build:[]string(nil)}, Original:"v1.2", Prefix:"v"}`},
+	}
+	for _, test := range tests {
+		if o := fmt.Sprintf(test.layout, ov); o != test.o {
+			t.Errorf("Formatting %q with %q, expected %q but got %q", ov.Original, test.layout, test.o, o)
+		}
+	}
+	if o := fmt.Sprint(ov); o != "v1.2" {
+		t.Errorf("Expected %q, got %q", "v1.2", o)
+	}
+	if o := fmt.Sprintln(&ov); o != "v1.2\n" {
+		t.Errorf("Expected %q, got %q", "v1.2\n", o)
+	}
+}
@@ -0,0 +1,223 @@
+// Package osv evaluates the affected ranges of OSV vulnerability records,
+// see https://ossf.github.io/osv-schema/
+package osv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/eugenmayer/semver/v4"
+)
+
+// RangeTypeSemver is the range type of events using semver versions.
+// Ranges of other types (ECOSYSTEM, GIT) are ignored.
+const RangeTypeSemver = "SEMVER"
+
+// Vulnerability is an OSV vulnerability record.
+type Vulnerability struct {
+	ID       string     `json:"id"`
+	Modified string     `json:"modified,omitempty"`
+	Aliases  []string   `json:"aliases,omitempty"`
+	Summary  string     `json:"summary,omitempty"`
+	Affected []Affected `json:"affected,omitempty"`
+}
+
+// Affected describes the affected versions of a package.
+type Affected struct {
+	Package  Package  `json:"package"`
+	Ranges   []Range  `json:"ranges,omitempty"`
+	Versions []string `json:"versions,omitempty"`
+}
+
+// Package identifies a package within an ecosystem.
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Purl      string `json:"purl,omitempty"`
+}
+
+// Range is a list of events describing when a vulnerability was
+// introduced and fixed.
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event is a single entry of a Range, exactly one field is set.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// ReadFile reads an OSV record from a JSON file.
+func ReadFile(path string) (*Vulnerability, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var vuln Vulnerability
+	if err := json.Unmarshal(data, &vuln); err != nil {
+		return nil, fmt.Errorf("Could not parse OSV record %q: %s", path, err)
+	}
+	return &vuln, nil
+}
+
+// ReadDir reads all OSV records from the JSON files of a directory,
+// sorted by file name.
+func ReadDir(dir string) ([]*Vulnerability, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	vulns := make([]*Vulnerability, 0, len(paths))
+	for _, path := range paths {
+		vuln, err := ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		vulns = append(vulns, vuln)
+	}
+	return vulns, nil
+}
+
+// Packages returns the entries of the affected package,
+// a package may be listed more than once.
+func (vuln *Vulnerability) Packages(ecosystem, name string) []Affected {
+	var out []Affected
+	for _, a := range vuln.Affected {
+		if a.Package.Ecosystem == ecosystem && a.Package.Name == name {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// Affects checks if the version v is affected by any SEMVER range
+// or listed in the affected versions.
+func (a Affected) Affects(v semver.Version) (bool, error) {
+	for _, s := range a.Versions {
+		if av, err := semver.Parse(s); err == nil && av.EQ(v) {
+			return true, nil
+		}
+	}
+	for _, r := range a.Ranges {
+		if r.Type != RangeTypeSemver {
+			continue
+		}
+		rs, err := r.RangeSet()
+		if err != nil {
+			return false, err
+		}
+		if rs.Range()(v) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// LowestFixed returns the lowest version fixed by any SEMVER range
+// which is greater than v. The bool is false if there is no such version.
+func (a Affected) LowestFixed(v semver.Version) (semver.Version, bool, error) {
+	var fixed semver.Version
+	found := false
+	for _, r := range a.Ranges {
+		if r.Type != RangeTypeSemver {
+			continue
+		}
+		for _, e := range r.Events {
+			if e.Fixed == "" {
+				continue
+			}
+			fv, err := semver.Parse(e.Fixed)
+			if err != nil {
+				return semver.Version{}, false, fmt.Errorf("Invalid fixed version %q: %s", e.Fixed, err)
+			}
+			if fv.GT(v) && (!found || fv.LT(fixed)) {
+				fixed, found = fv, true
+			}
+		}
+	}
+	return fixed, found, nil
+}
+
+// RangeSet converts the events of a SEMVER range into a RangeSet.
+// Every introduced event starts a set, which is closed by the next fixed
+// (exclusive) or last_affected (inclusive) event. Limits restrict all sets.
+// An introduced version of "0" means all versions before the next fixed event.
+func (r Range) RangeSet() (semver.RangeSet, error) {
+	if r.Type != RangeTypeSemver {
+		return nil, fmt.Errorf("Unsupported range type %q", r.Type)
+	}
+
+	type event struct {
+		Event
+		v semver.Version
+	}
+	events := make([]event, 0, len(r.Events))
+	for _, e := range r.Events {
+		s := e.Introduced + e.Fixed + e.LastAffected + e.Limit
+		if s == "" || s != e.Introduced && s != e.Fixed && s != e.LastAffected && s != e.Limit {
+			return nil, fmt.Errorf("Event %+v must have exactly one field set", e)
+		}
+		var v semver.Version
+		if e.Introduced == "0" {
+			// The lowest possible version
+			v = semver.Version{Pre: []semver.PRVersion{{IsNum: true}}}
+		} else if e.Limit != "*" {
+			var err error
+			if v, err = semver.Parse(s); err != nil {
+				return nil, fmt.Errorf("Invalid event version %q: %s", s, err)
+			}
+		}
+		events = append(events, event{e, v})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].v.LT(events[j].v)
+	})
+
+	var rs semver.RangeSet
+	var limits []semver.Condition
+	var set []semver.Condition
+	for _, e := range events {
+		switch {
+		case e.Introduced != "":
+			if set == nil {
+				set = []semver.Condition{{Operator: ">=", Version: e.v}}
+			}
+		case e.Fixed != "":
+			if set != nil {
+				rs = append(rs, append(set, semver.Condition{Operator: "<", Version: e.v}))
+				set = nil
+			}
+		case e.LastAffected != "":
+			if set != nil {
+				rs = append(rs, append(set, semver.Condition{Operator: "<=", Version: e.v}))
+				set = nil
+			}
+		case e.Limit != "*":
+			limits = append(limits, semver.Condition{Operator: "<", Version: e.v})
+		}
+	}
+	if set != nil {
+		rs = append(rs, set)
+	}
+
+	if len(limits) == 0 {
+		return rs, nil
+	}
+	// A version is affected if it is below any of the limits
+	var limited semver.RangeSet
+	for _, set := range rs {
+		for _, l := range limits {
+			limited = append(limited, append(append([]semver.Condition{}, set...), l))
+		}
+	}
+	return limited, nil
+}
@@ -0,0 +1,129 @@
+package osv
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/eugenmayer/semver/v4"
+)
+
+func TestReadDir(t *testing.T) {
+	vulns, err := ReadDir("testdata")
+	if err != nil {
+		t.Fatalf("Unexpected error %q", err)
+	}
+	if len(vulns) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(vulns))
+	}
+	if vulns[0].ID != "GHSA-aaaa-0001" || vulns[1].ID != "GHSA-aaaa-0002" {
+		t.Errorf("Unexpected records %q, %q", vulns[0].ID, vulns[1].ID)
+	}
+	if n := len(vulns[0].Packages("npm", "example-lib")); n != 1 {
+		t.Errorf("Expected 1 affected entry, got %d", n)
+	}
+	if n := len(vulns[0].Packages("PyPI", "example-lib")); n != 0 {
+		t.Errorf("Expected no affected entry, got %d", n)
+	}
+
+	if _, err := ReadFile(filepath.Join("testdata", "invalid.json.txt")); err == nil {
+		t.Errorf("Expected error for invalid record, got none")
+	}
+	if _, err := ReadFile(filepath.Join("testdata", "missing.json")); err == nil {
+		t.Errorf("Expected error for missing file, got none")
+	}
+}
+
+func TestRangeSet(t *testing.T) {
+	tests := []struct {
+		events []Event
+		o      string
+	}{
+		{[]Event{{Introduced: "0"}, {Fixed: "1.2.4"}}, ">=0.0.0-0 <1.2.4"},
+		{[]Event{{Introduced: "1.0.0"}, {LastAffected: "1.4.2"}}, ">=1.0.0 <=1.4.2"},
+		{[]Event{{Introduced: "1.0.0"}}, ">=1.0.0"},
+		{[]Event{{Fixed: "2.0.0"}, {Introduced: "1.0.0"}, {Introduced: "3.0.0"}, {Fixed: "3.1.0"}}, ">=1.0.0 <2.0.0 || >=3.0.0 <3.1.0"},
+		{[]Event{{Introduced: "1.0.0"}, {Introduced: "1.1.0"}, {Fixed: "2.0.0"}, {Fixed: "2.1.0"}}, ">=1.0.0 <2.0.0"},
+		{[]Event{{Introduced: "1.0.0"}, {Limit: "1.5.0"}, {Fixed: "2.0.0"}}, ">=1.0.0 <2.0.0 <1.5.0"},
+		{[]Event{{Introduced: "1.0.0"}, {Limit: "*"}}, ">=1.0.0"},
+		{[]Event{{Fixed: "1.0.0"}}, ""},
+		// Errors
+		{[]Event{{Introduced: "1.0"}}, "error"},
+		{[]Event{{Introduced: "1.0.0", Fixed: "2.0.0"}}, "error"},
+		{[]Event{{}}, "error"},
+	}
+
+	for _, tc := range tests {
+		rs, err := Range{Type: RangeTypeSemver, Events: tc.events}.RangeSet()
+		if err != nil {
+			if tc.o != "error" {
+				t.Errorf("Invalid for case %+v: Expected %q, got error %q", tc.events, tc.o, err)
+			}
+			continue
+		}
+		if tc.o == "error" {
+			t.Errorf("Invalid for case %+v: Expected error, got %q", tc.events, rs.String())
+		} else if rs.String() != tc.o {
+			t.Errorf("Invalid for case %+v: Expected %q, got: %q", tc.events, tc.o, rs.String())
+		}
+	}
+
+	if _, err := (Range{Type: "GIT"}).RangeSet(); err == nil {
+		t.Errorf("Expected error for GIT range, got none")
+	}
+}
+
+func TestAffects(t *testing.T) {
+	vulns, err := ReadDir("testdata")
+	if err != nil {
+		t.Fatalf("Unexpected error %q", err)
+	}
+	tests := []struct {
+		vuln     int
+		pkg      string
+		v        string
+		affected bool
+		fixed    string
+	}{
+		{0, "example-lib", "0.0.1-alpha", true, "1.2.4"},
+		{0, "example-lib", "1.0.0", true, "1.2.4"},
+		{0, "example-lib", "1.2.4", false, "2.3.1"},
+		{0, "example-lib", "2.0.0-rc.1", false, "2.3.1"},
+		{0, "example-lib", "2.0.0", true, "2.3.1"},
+		{0, "example-lib", "2.3.1", false, ""},
+		{0, "example-lib", "3.0.0-alpha", false, ""},
+		{0, "example-lib", "3.0.0-beta.1", true, ""},
+		{0, "example-lib", "4.0.0", true, ""},
+		{0, "other-lib", "0.9.0", true, ""},
+		{0, "other-lib", "1.4.2", true, ""},
+		{0, "other-lib", "1.4.3", false, ""},
+		{1, "example-lib", "2.0.0", false, "2.5.0"},
+		{1, "example-lib", "2.3.9", true, "2.5.0"},
+		{1, "example-lib", "2.4.0", false, "2.5.0"},
+	}
+
+	for _, tc := range tests {
+		v := semver.MustParse(tc.v)
+		a := vulns[tc.vuln].Packages("npm", tc.pkg)[0]
+		affected, err := a.Affects(v)
+		if err != nil {
+			t.Errorf("Unexpected error for %s@%s: %q", tc.pkg, tc.v, err)
+			continue
+		}
+		if affected != tc.affected {
+			t.Errorf("Invalid for %s@%s: Expected affected %t, got: %t", tc.pkg, tc.v, tc.affected, affected)
+		}
+
+		fixed, ok, err := a.LowestFixed(v)
+		if err != nil {
+			t.Errorf("Unexpected error for %s@%s: %q", tc.pkg, tc.v, err)
+			continue
+		}
+		if !ok {
+			if tc.fixed != "" {
+				t.Errorf("Invalid for %s@%s: Expected fixed %q, got none", tc.pkg, tc.v, tc.fixed)
+			}
+		} else if fixed.String() != tc.fixed {
+			t.Errorf("Invalid for %s@%s: Expected fixed %q, got: %q", tc.pkg, tc.v, tc.fixed, fixed.String())
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// pseudoTimeFormat is the layout of the timestamp in a pseudo-version.
+const pseudoTimeFormat = "20060102150405"
+
+// PseudoVersion is a Go module pseudo-version like
+// "v0.0.0-20191109021931-daa7c04131f5", referring to a commit
+// instead of a tagged release.
+type PseudoVersion struct {
+	// Version is the pseudo-version itself.
+	Version Version
+	// Base is the tagged version the pseudo-version is derived from,
+	// or nil for pseudo-versions like vX.0.0-yyyymmddhhmmss-abcdefabcdef.
+	Base *Version
+	// Time is the UTC commit time.
+	Time time.Time
+	// Revision is the commit hash prefix, like "daa7c04131f5".
+	Revision string
+	// Incompatible is true for the "+incompatible" suffix of modules
+	// with a major version of 2 or higher but without go.mod.
+	Incompatible bool
+}
+
+// ParsePseudoVersion parses a Go module pseudo-version in one of the forms
+//   - "vX.0.0-yyyymmddhhmmss-abcdefabcdef" without base version
+//   - "vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef" based on vX.Y.Z-pre
+//   - "vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef" based on vX.Y.Z
+//
+// optionally followed by "+incompatible".
+// If s is no valid pseudo-version an error is returned.
+func ParsePseudoVersion(s string) (PseudoVersion, error) {
+	v, err := Parse(s)
+	if err != nil {
+		return PseudoVersion{}, err
+	}
+	if len(v.Pre) == 0 {
+		return PseudoVersion{}, fmt.Errorf("No pseudo-version %q: Missing prerelease", s)
+	}
+
+	p := PseudoVersion{Version: v}
+	last := v.Pre[len(v.Pre)-1].String()
+	i := strings.IndexRune(last, '-')
+	if i != len(pseudoTimeFormat) || !containsOnly(last[:i], numbers) {
+		return PseudoVersion{}, fmt.Errorf("No pseudo-version %q: Missing timestamp", s)
+	}
+	if p.Time, err = time.Parse(pseudoTimeFormat, last[:i]); err != nil {
+		return PseudoVersion{}, fmt.Errorf("No pseudo-version %q: Invalid timestamp: %s", s, err)
+	}
+	p.Revision = last[i+1:]
+	if err := validateRevision(p.Revision); err != nil {
+		return PseudoVersion{}, fmt.Errorf("No pseudo-version %q: %s", s, err)
+	}
+
+	for _, b := range v.Build {
+		if b != "incompatible" || len(v.Build) > 1 {
+			return PseudoVersion{}, fmt.Errorf("No pseudo-version %q: Unexpected build meta data", s)
+		}
+		p.Incompatible = true
+	}
+
+	switch {
+	case len(v.Pre) == 1:
+		if v.Minor != 0 || v.Patch != 0 {
+			return PseudoVersion{}, fmt.Errorf("No pseudo-version %q: Expected vX.0.0 without base version", s)
+		}
+	case !v.Pre[len(v.Pre)-2].IsNum || v.Pre[len(v.Pre)-2].VersionNum != 0 || len(v.Pre[len(v.Pre)-2].VersionStr) > 0:
+		return PseudoVersion{}, fmt.Errorf("No pseudo-version %q: Expected 0 before timestamp", s)
+	case len(v.Pre) == 2:
+		if v.Patch == 0 {
+			return PseudoVersion{}, fmt.Errorf("No pseudo-version %q: Patch of release based pseudo-version must not be 0", s)
+		}
+		base := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch - 1, Build: v.Build}
+		p.Base = &base
+	default:
+		base := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Pre: v.Pre[:len(v.Pre)-2], Build: v.Build}
+		p.Base = &base
+	}
+	return p, nil
+}
+
+// IsPseudoVersion checks if s is a Go module pseudo-version.
+func IsPseudoVersion(s string) bool {
+	_, err := ParsePseudoVersion(s)
+	return err == nil
+}
+
+// NewPseudoVersion creates the pseudo-version of a commit after the tagged
+// version base at time t with the commit hash rev, shortened to 12 characters:
+//   - v1.2.3 becomes v1.2.4-0.yyyymmddhhmmss-abcdefabcdef
+//   - v1.2.3-pre becomes v1.2.3-pre.0.yyyymmddhhmmss-abcdefabcdef
+//
+// The build meta data of base, like "+incompatible", is kept.
+func NewPseudoVersion(base Version, t time.Time, rev string) (PseudoVersion, error) {
+	id, err := pseudoIdentifier(t, rev)
+	if err != nil {
+		return PseudoVersion{}, err
+	}
+	for _, b := range base.Build {
+		if b != "incompatible" || len(base.Build) > 1 {
+			return PseudoVersion{}, fmt.Errorf("Unexpected build meta data in base version %q", base.String())
+		}
+	}
+
+	v := Version{Major: base.Major, Minor: base.Minor, Patch: base.Patch, Build: base.Build}
+	if len(base.Pre) > 0 {
+		v.Pre = append(v.Pre, base.Pre...)
+	} else {
+		v.Patch++
+	}
+	v.Pre = append(v.Pre, PRVersion{IsNum: true}, id)
+	return ParsePseudoVersion(v.String())
+}
+
+// NewInitialPseudoVersion creates the pseudo-version of a commit without
+// any tagged version before, vMAJOR.0.0-yyyymmddhhmmss-abcdefabcdef,
+// at time t with the commit hash rev, shortened to 12 characters.
+func NewInitialPseudoVersion(major uint64, t time.Time, rev string) (PseudoVersion, error) {
+	id, err := pseudoIdentifier(t, rev)
+	if err != nil {
+		return PseudoVersion{}, err
+	}
+	v := Version{Major: major, Pre: []PRVersion{id}}
+	return ParsePseudoVersion(v.String())
+}
+
+// pseudoIdentifier creates the "yyyymmddhhmmss-abcdefabcdef" prerelease identifier.
+func pseudoIdentifier(t time.Time, rev string) (PRVersion, error) {
+	if len(rev) > 12 {
+		rev = rev[:12]
+	}
+	if err := validateRevision(rev); err != nil {
+		return PRVersion{}, err
+	}
+	return PRVersion{VersionStr: t.UTC().Format(pseudoTimeFormat) + "-" + rev}, nil
+}
+
+// validateRevision checks a commit hash used in a pseudo-version.
+func validateRevision(rev string) error {
+	if len(rev) == 0 {
+		return errors.New("Revision is empty")
+	}
+	if !containsOnly(rev, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"+numbers) {
+		return fmt.Errorf("Invalid character(s) found in revision %q", rev)
+	}
+	return nil
+}
+
+// String returns the pseudo-version with "v" prefix, as used by Go modules.
+func (p PseudoVersion) String() string {
+	return "v" + p.Version.String()
+}
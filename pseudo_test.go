@@ -0,0 +1,124 @@
+package semver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePseudoVersion(t *testing.T) {
+	tests := []struct {
+		s            string
+		base         string
+		time         string
+		rev          string
+		incompatible bool
+	}{
+		{"v0.0.0-20191109021931-daa7c04131f5", "", "2019-11-09T02:19:31Z", "daa7c04131f5", false},
+		{"v2.0.0-20191109021931-daa7c04131f5+incompatible", "", "2019-11-09T02:19:31Z", "daa7c04131f5", true},
+		{"v1.2.4-0.20191109021931-daa7c04131f5", "1.2.3", "2019-11-09T02:19:31Z", "daa7c04131f5", false},
+		{"v1.2.3-pre.0.20191109021931-daa7c04131f5", "1.2.3-pre", "2019-11-09T02:19:31Z", "daa7c04131f5", false},
+		{"v1.2.3-rc.1.0.20191109021931-daa7c04131f5", "1.2.3-rc.1", "2019-11-09T02:19:31Z", "daa7c04131f5", false},
+		{"v3.1.1-0.20200101000000-abcdef123456+incompatible", "3.1.0+incompatible", "2020-01-01T00:00:00Z", "abcdef123456", true},
+		{"0.0.0-20191109021931-daa7c04131f5", "", "2019-11-09T02:19:31Z", "daa7c04131f5", false},
+		// Errors
+		{"v1.2.3", "error", "", "", false},
+		{"v1.2.3-pre", "error", "", "", false},
+		{"v1.2.0-20191109021931-daa7c04131f5", "error", "", "", false},
+		{"v1.2.0-0.20191109021931-daa7c04131f5", "error", "", "", false},
+		{"v1.2.3-1.20191109021931-daa7c04131f5", "error", "", "", false},
+		{"v1.2.3-pre.20191109021931-daa7c04131f5", "error", "", "", false},
+		{"v0.0.0-2019110902193-daa7c04131f5", "error", "", "", false},
+		{"v0.0.0-20191309021931-daa7c04131f5", "error", "", "", false},
+		{"v0.0.0-20191109021931-", "error", "", "", false},
+		{"v0.0.0-20191109021931-daa7-c04131f5", "error", "", "", false},
+		{"v0.0.0-20191109021931-daa7c04131f5+build", "error", "", "", false},
+		{"not a version", "error", "", "", false},
+	}
+
+	for _, test := range tests {
+		p, err := ParsePseudoVersion(test.s)
+		if err != nil {
+			if test.base != "error" {
+				t.Errorf("Error parsing %q: %q", test.s, err)
+			}
+			if IsPseudoVersion(test.s) {
+				t.Errorf("%q should not be a pseudo-version", test.s)
+			}
+			continue
+		}
+		if test.base == "error" {
+			t.Errorf("Parsing %q, expected error but got %q", test.s, p)
+			continue
+		}
+		if !IsPseudoVersion(test.s) {
+			t.Errorf("%q should be a pseudo-version", test.s)
+		}
+		if p.Base == nil {
+			if test.base != "" {
+				t.Errorf("Parsing %q, expected base %q but got none", test.s, test.base)
+			}
+		} else if p.Base.String() != test.base {
+			t.Errorf("Parsing %q, expected base %q but got %q", test.s, test.base, p.Base)
+		}
+		if ts := p.Time.Format(time.RFC3339); ts != test.time {
+			t.Errorf("Parsing %q, expected time %q but got %q", test.s, test.time, ts)
+		}
+		if p.Revision != test.rev {
+			t.Errorf("Parsing %q, expected revision %q but got %q", test.s, test.rev, p.Revision)
+		}
+		if p.Incompatible != test.incompatible {
+			t.Errorf("Parsing %q, expected incompatible %t but got %t", test.s, test.incompatible, p.Incompatible)
+		}
+		if p.String() != "v"+p.Version.String() {
+			t.Errorf("Parsing %q, unexpected string %q", test.s, p)
+		}
+	}
+}
+
+func TestNewPseudoVersion(t *testing.T) {
+	ts := time.Date(2019, 11, 9, 3, 19, 31, 0, time.FixedZone("CET", 3600))
+	rev := "daa7c04131f5e4b3a9c2f1d0e8b7a6c5d4e3f2a1"
+	tests := []struct {
+		base string
+		o    string
+	}{
+		{"1.2.3", "v1.2.4-0.20191109021931-daa7c04131f5"},
+		{"1.2.3-pre", "v1.2.3-pre.0.20191109021931-daa7c04131f5"},
+		{"2.0.0+incompatible", "v2.0.1-0.20191109021931-daa7c04131f5+incompatible"},
+		// Errors
+		{"1.2.3+build", ""},
+	}
+	for _, test := range tests {
+		base := MustParse(test.base)
+		p, err := NewPseudoVersion(base, ts, rev)
+		if err != nil {
+			if test.o != "" {
+				t.Errorf("Error creating pseudo-version of %q: %q", test.base, err)
+			}
+			continue
+		}
+		if test.o == "" {
+			t.Errorf("Creating pseudo-version of %q, expected error but got %q", test.base, p)
+		} else if p.String() != test.o {
+			t.Errorf("Creating pseudo-version of %q, expected %q but got %q", test.base, test.o, p)
+		} else if p.Base == nil || p.Base.String() != test.base {
+			t.Errorf("Creating pseudo-version of %q, unexpected base %v", test.base, p.Base)
+		} else if !p.Version.GT(base) {
+			t.Errorf("Pseudo-version %q should be greater than %q", p, base)
+		}
+	}
+
+	p, err := NewInitialPseudoVersion(0, ts, rev)
+	if err != nil {
+		t.Fatalf("Unexpected error %q", err)
+	}
+	if p.String() != "v0.0.0-20191109021931-daa7c04131f5" || p.Base != nil {
+		t.Errorf("Unexpected initial pseudo-version %q", p)
+	}
+	if _, err := NewInitialPseudoVersion(0, ts, ""); err == nil {
+		t.Errorf("Expected error for empty revision, got none")
+	}
+	if _, err := NewPseudoVersion(MustParse("1.0.0"), ts, "not-a-hash"); err == nil {
+		t.Errorf("Expected error for invalid revision, got none")
+	}
+}
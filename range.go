@@ -88,9 +88,97 @@ func (rf Range) AND(f Range) Range {
 //
 //  - `>1.0.0 <2.0.0 || >3.0.0 !4.2.1` would match `1.2.3`, `1.9.9`, `3.1.1`, but not `4.2.1`, `2.1.1`
 func ParseRange(s string) (Range, error) {
+	return ParseRangeWithOptions(s, RangeOptions{})
+}
+
+// RangeDialect selects the range syntax of ParseRangeWithOptions.
+type RangeDialect int
+
+const (
+	// DialectNPM is the npm (node-semver) syntax described at ParseRange.
+	DialectNPM RangeDialect = iota
+	// DialectRubyGems is the syntax of RubyGems and Terraform.
+	// Constraints are separated by comma and linked by logical AND,
+	// "~>" is the pessimistic operator:
+	//   - "~> 1.2" is ">=1.2.0 <2.0.0-0"
+	//   - "~> 1.2.3" is ">=1.2.3 <1.3.0-0"
+	//   - ">= 1.2, < 3.0, != 2.1.0"
+	DialectRubyGems
+	// DialectCargo is the version requirement syntax of Rust's Cargo.
+	// Requirements are separated by comma and linked by logical AND,
+	// a bare version is a caret requirement:
+	//   - "1.2.3" is "^1.2.3", so ">=1.2.3 <2.0.0-0"
+	//   - "~1.2", "1.*", "*"
+	//   - ">= 1.2, < 1.5"
+	//
+	// Like Cargo, upper bounds exclude the prereleases of the bound,
+	// so "1.2.3" does not match 2.0.0-alpha.1.
+	DialectCargo
+)
+
+// DialectTerraform is an alias for DialectRubyGems.
+const DialectTerraform = DialectRubyGems
+
+// RangeOptions controls how ParseRangeWithOptions interprets a range.
+// The zero value behaves like ParseRange.
+type RangeOptions struct {
+	// Strict only accepts the plain comparators listed in ParseRange.
+	// npm sugar like carets, tildes, x-ranges, stars and hyphen ranges
+	// is rejected with an error naming the construct found, as are the
+	// pessimistic operator of DialectRubyGems and the bare (caret)
+	// versions of DialectCargo.
+	Strict bool
+	// Dialect selects the range syntax, DialectNPM by default.
+	Dialect RangeDialect
+}
+
+// ParseRangeWithOptions parses a range like ParseRange, using opts
+// to restrict or extend the accepted syntax.
+func ParseRangeWithOptions(s string, opts RangeOptions) (Range, error) {
+	rs, err := ParseRangeSetWithOptions(s, opts)
+	if err != nil {
+		return nil, err
+	}
+	return rs.Range(), nil
+}
+
+// ParseRangeSet parses a range like ParseRange and returns its RangeSet.
+func ParseRangeSet(s string) (RangeSet, error) {
+	return ParseRangeSetWithOptions(s, RangeOptions{})
+}
+
+// ParseRangeSetWithOptions parses a range like ParseRangeWithOptions
+// and returns its RangeSet.
+func ParseRangeSetWithOptions(s string, opts RangeOptions) (RangeSet, error) {
+	switch opts.Dialect {
+	case DialectNPM:
+	case DialectRubyGems:
+		parts, err := parseRubyGemsRange(s, opts.Strict)
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse Range %q: %s", s, err)
+		}
+		return buildRangeSet(parts)
+	case DialectCargo:
+		parts, err := parseCargoRange(s, opts.Strict)
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse Range %q: %s", s, err)
+		}
+		return buildRangeSet(parts)
+	default:
+		return nil, fmt.Errorf("Unknown range dialect %d", opts.Dialect)
+	}
+
 	var expandedParts [][]string
 	// split on boolean or ||
 	orParts := regexp.MustCompile("\\s*\\|\\|\\s*").Split(s, -1)
+	if opts.Strict {
+		re := getRegex()
+		for _, part := range orParts {
+			if err := checkStrictRange(re, part); err != nil {
+				return nil, err
+			}
+		}
+	}
 	for _, part := range orParts {
 		parsed := parseRange(part)
 		if len(parsed) > 0 {
@@ -98,35 +186,17 @@ func ParseRange(s string) (Range, error) {
 		}
 	}
 
-	var orFn Range
-	for _, p := range expandedParts {
-		var andFn Range
-		for _, ap := range p {
-			opStr, vStr, err := splitComparatorVersion(ap)
-			if err != nil {
-				return nil, err
-			}
-			vr, err := buildVersionRange(opStr, vStr)
-			if err != nil {
-				return nil, fmt.Errorf("Could not parse Range %q: %s", ap, err)
-			}
-			rf := vr.rangeFunc()
-
-			// Set function
-			if andFn == nil {
-				andFn = rf
-			} else { // Combine with existing function
-				andFn = andFn.AND(rf)
-			}
-		}
-		if orFn == nil {
-			orFn = andFn
-		} else {
-			orFn = orFn.OR(andFn)
-		}
+	return buildRangeSet(expandedParts)
+}
 
+// buildRange builds a Range from already expanded comparators.
+// The outer slice is linked by logical OR, the inner slices by logical AND.
+func buildRange(expandedParts [][]string) (Range, error) {
+	rs, err := buildRangeSet(expandedParts)
+	if err != nil {
+		return nil, err
 	}
-	return orFn, nil
+	return rs.Range(), nil
 }
 
 // buildVersionRange takes a slice of 2: operator and version
@@ -209,3 +279,26 @@ func MustParseRange(s string) Range {
 	}
 	return r
 }
+
+// parseShortVersion parses a version like "1", "1.2" or "1.2.3-beta.1",
+// filling up missing minor and patch numbers with 0.
+func parseShortVersion(s string) (Version, error) {
+	i := strings.IndexAny(s, "-+")
+	if i == -1 {
+		i = len(s)
+	}
+
+	v, err := ParseTolerant(s[:i])
+	if err != nil {
+		return Version{}, fmt.Errorf("Invalid version %q: %s", s, err)
+	}
+	if i == len(s) {
+		return v, nil
+	}
+
+	v, err = Parse(v.String() + s[i:])
+	if err != nil {
+		return Version{}, fmt.Errorf("Invalid version %q: %s", s, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,65 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// cargoOperators are the operators of a Cargo version requirement,
+// longest first.
+var cargoOperators = []string{">=", "<=", "^", "~", "=", ">", "<"}
+
+// parseCargoRange expands comma separated Cargo version requirements
+// into comparators.
+func parseCargoRange(s string, strict bool) ([][]string, error) {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil, errors.New("Range is empty")
+	}
+
+	re := getRegex()
+	var out []string
+	for _, c := range strings.Split(s, ",") {
+		c = strings.TrimSpace(c)
+		if len(c) == 0 {
+			return nil, errors.New("Empty requirement")
+		}
+
+		op := ""
+		for _, o := range cargoOperators {
+			if strings.HasPrefix(c, o) {
+				op = o
+				break
+			}
+		}
+		// Only the operator may be followed by spaces
+		c = op + strings.TrimSpace(c[len(op):])
+		if strings.IndexFunc(c, unicode.IsSpace) != -1 {
+			return nil, fmt.Errorf("Invalid requirement %q", c)
+		}
+		if op == "" && c != "*" {
+			if !unicode.IsDigit(rune(c[0])) {
+				return nil, fmt.Errorf("Invalid requirement %q", c)
+			}
+			// A bare version is a caret requirement, unless it has wildcards
+			if !strings.ContainsAny(c, "*xX") {
+				op = "^"
+			}
+		}
+		req := op + strings.TrimPrefix(c, op)
+
+		if !re["CARET"].MatchString(req) && !re["TILDE"].MatchString(req) && !re["XRANGE"].MatchString(req) {
+			return nil, fmt.Errorf("Invalid requirement %q", c)
+		}
+		if strict {
+			if sugar := rangeSugar(re, req); sugar != "" {
+				return nil, fmt.Errorf("%s not allowed in strict mode: %q", sugar, c)
+			}
+		}
+		// Like Cargo, upper bounds exclude prereleases of the bound itself:
+		// `^1.2.3` => `>=1.2.3 <2.0.0-0`, so 2.0.0-alpha.1 does not match
+		out = append(out, excludeUpperPrereleases(strings.Fields(parseComparatorString(re, req)))...)
+	}
+	return [][]string{out}, nil
+}
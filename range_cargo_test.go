@@ -0,0 +1,137 @@
+package semver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Examples taken from https://doc.rust-lang.org/cargo/reference/specifying-dependencies.html
+func TestParseCargoRangeExpansion(t *testing.T) {
+	tests := []struct {
+		i string
+		o [][]string
+	}{
+		// Default requirements
+		{"1.2.3", [][]string{{">=1.2.3", "<2.0.0-0"}}},
+		{"1.2", [][]string{{">=1.2.0", "<2.0.0-0"}}},
+		{"1", [][]string{{">=1.0.0", "<2.0.0-0"}}},
+		{"0.2.3", [][]string{{">=0.2.3", "<0.3.0-0"}}},
+		{"0.2", [][]string{{">=0.2.0", "<0.3.0-0"}}},
+		{"0.0.3", [][]string{{">=0.0.3", "<0.0.4-0"}}},
+		{"0.0", [][]string{{">=0.0.0", "<0.1.0-0"}}},
+		{"0", [][]string{{">=0.0.0", "<1.0.0-0"}}},
+		// Caret requirements
+		{"^1.2.3", [][]string{{">=1.2.3", "<2.0.0-0"}}},
+		{"^0.0.3", [][]string{{">=0.0.3", "<0.0.4-0"}}},
+		// Tilde requirements
+		{"~1.2.3", [][]string{{">=1.2.3", "<1.3.0-0"}}},
+		{"~1.2", [][]string{{">=1.2.0", "<1.3.0-0"}}},
+		{"~1", [][]string{{">=1.0.0", "<2.0.0-0"}}},
+		// Wildcard requirements
+		{"*", [][]string{{">=0.0.0"}}},
+		{"1.*", [][]string{{">=1.0.0", "<2.0.0-0"}}},
+		{"1.2.*", [][]string{{">=1.2.0", "<1.3.0-0"}}},
+		// Comparison requirements
+		{">= 1.2.0", [][]string{{">=1.2.0"}}},
+		{"> 1", [][]string{{">=2.0.0"}}},
+		{"< 2", [][]string{{"<2.0.0-0"}}},
+		{"= 1.2.3", [][]string{{"=1.2.3"}}},
+		// Multiple requirements
+		{">= 1.2, < 1.5", [][]string{{">=1.2.0", "<1.5.0-0"}}},
+		{"^1.2, != 1.5.0", nil},
+		// Errors
+		{"", nil},
+		{"1.2.3,", nil},
+		{"1.2.3 || 2.0.0", nil},
+		{"1.2.3 - 2.0.0", nil},
+		{"foo", nil},
+	}
+
+	for _, tc := range tests {
+		o, err := parseCargoRange(tc.i, false)
+		if err != nil {
+			if tc.o != nil {
+				t.Errorf("Invalid for case %q: Expected %q, got error %q", tc.i, tc.o, err)
+			}
+		} else if tc.o == nil {
+			t.Errorf("Invalid for case %q: Expected error, got %q", tc.i, o)
+		} else if !reflect.DeepEqual(tc.o, o) {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+	}
+}
+
+func TestParseRangeCargoDialect(t *testing.T) {
+	type tv struct {
+		v string
+		b bool
+	}
+	tests := []struct {
+		i string
+		t []tv
+	}{
+		{"1.2.3", []tv{
+			{"1.2.2", false},
+			{"1.2.3", true},
+			{"1.9.0", true},
+			{"2.0.0-alpha.1", false},
+			{"2.0.0", false},
+		}},
+		{"~1.2", []tv{
+			{"1.2.9", true},
+			{"1.3.0-beta", false},
+		}},
+		{"1.*", []tv{
+			{"1.9.0", true},
+			{"2.0.0-rc.1", false},
+		}},
+		{">= 1.2, < 1.5", []tv{
+			{"1.1.9", false},
+			{"1.2.0", true},
+			{"1.4.9", true},
+			{"1.5.0-rc.1", false},
+			{"1.5.0", false},
+		}},
+		{"< 2.0.0-beta", []tv{
+			{"2.0.0-alpha", true},
+			{"2.0.0-beta", false},
+		}},
+	}
+
+	for _, tc := range tests {
+		r, err := ParseRangeWithOptions(tc.i, RangeOptions{Dialect: DialectCargo})
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		for _, tvc := range tc.t {
+			v := MustParse(tvc.v)
+			if res := r(v); res != tvc.b {
+				t.Errorf("Invalid for case %q matching %q: Expected %t, got: %t", tc.i, tvc.v, tvc.b, res)
+			}
+		}
+	}
+
+	strictTests := []struct {
+		i     string
+		sugar string
+	}{
+		{">= 1.2.0, < 1.5.0", ""},
+		{"= 1.2.3", ""},
+		{"1.2.3", "Caret range"},
+		{"~1.2", "Tilde range"},
+		{"1.*", "X-range"},
+		{"*", "Wildcard"},
+	}
+	for _, tc := range strictTests {
+		_, err := ParseRangeWithOptions(tc.i, RangeOptions{Dialect: DialectCargo, Strict: true})
+		if tc.sugar == "" {
+			if err != nil {
+				t.Errorf("Invalid for case %q: Expected no error in strict mode, got %q", tc.i, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tc.sugar) {
+			t.Errorf("Invalid for case %q: Expected %s error in strict mode, got %v", tc.i, tc.sugar, err)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// composerStabilities are the Composer stability levels, least stable first.
+var composerStabilities = []string{"dev", "alpha", "beta", "rc", "stable"}
+
+// composerStabilityRegex matches the prerelease identifiers composerStability
+// knows about.
+var composerStabilityRegex = regexp.MustCompile("^(rc|beta|b|alpha|a|patch|pl|p)\\d*$")
+
+// composerOrRegex splits a constraint at "||" or the deprecated "|".
+var composerOrRegex = regexp.MustCompile("\\s*\\|\\|?\\s*")
+
+// composerFlagRegex matches a stability flag like "@beta".
+var composerFlagRegex = regexp.MustCompile("@([a-zA-Z]*)")
+
+// composerTildeCaretTrimRegex matches the spaces after a tilde or caret.
+var composerTildeCaretTrimRegex = regexp.MustCompile("\\s*(~|\\^)\\s+")
+
+// ParseComposerRange parses a Composer (PHP) version constraint and returns a Range.
+// If the constraint could not be parsed an error is returned.
+//
+// Valid constraints are:
+//   - "1.0.2", "=1.0.2", ">1.0", ">=1.0", "<1.0", "<=1.0", "!=1.0"
+//   - "1.0 - 2.0" hyphen range, >=1.0.0 <2.1.0-0
+//   - "1.0.*" wildcard, >=1.0.0 <1.1.0-0
+//   - "~1.2" tilde range, >=1.2.0 <2.0.0-0
+//   - "~1.2.3" tilde range, >=1.2.3 <1.3.0-0
+//   - "^1.2.3" caret range, >=1.2.3 <2.0.0-0
+//
+// Constraints separated by space or comma are linked by logical AND,
+// constraints separated by "||" (or the deprecated "|") by logical OR:
+//   - ">=1.0 <1.1 || >=1.2"
+//   - "^1.2 || ~2.0"
+//
+// Like Composer, partial versions are filled up with 0, so "1.0" is exactly
+// 1.0.0 and "<=1.0" does not match 1.0.5. Only wildcards like "1.0.*" cover
+// a whole series.
+// Upper bounds exclude the prereleases of the bound, so "<1.1" is "<1.1.0-0".
+// Versions less stable than the minimum stability are not accepted.
+// The minimum stability is "stable", unless lowered by a stability flag like
+// "1.0.*@beta" or "@dev", or by a prerelease used in a constraint like
+// ">=1.0.0-beta2". Stabilities are derived from the first prerelease
+// identifier: dev < alpha < beta < RC < stable.
+func ParseComposerRange(s string) (Range, error) {
+	parts, minStability, err := parseComposerRange(s)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse Composer constraint %q: %s", s, err)
+	}
+	rs, err := buildRangeSet(parts)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse Composer constraint %q: %s", s, err)
+	}
+
+	return rs.Range().AND(func(v Version) bool {
+		return composerStability(v) >= minStability
+	}), nil
+}
+
+// parseComposerRange expands a Composer constraint into comparators and
+// returns the minimum stability requested by stability flags or prereleases.
+func parseComposerRange(s string) ([][]string, int, error) {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil, 0, errors.New("Constraint is empty")
+	}
+
+	re := getRegex()
+	minStability := len(composerStabilities) - 1
+	var parts [][]string
+	for _, part := range composerOrRegex.Split(strings.TrimSpace(s), -1) {
+		// Stability flags apply to the whole constraint
+		flagged := false
+		for {
+			m := composerFlagRegex.FindStringSubmatchIndex(part)
+			if m == nil {
+				break
+			}
+			st := composerStabilityIndex(part[m[2]:m[3]])
+			if st == -1 {
+				return nil, 0, fmt.Errorf("Unknown stability flag %q", part[m[0]:m[1]])
+			}
+			if st < minStability {
+				minStability = st
+			}
+			part = part[:m[0]] + part[m[1]:]
+			flagged = true
+		}
+		if len(strings.TrimSpace(part)) == 0 && !flagged {
+			return nil, 0, fmt.Errorf("Empty constraint in %q", s)
+		}
+		if strings.Contains(part, "dev-") {
+			return nil, 0, fmt.Errorf("Branch constraint not supported in %q", part)
+		}
+
+		// `1.0 - 2.0` => `>=1.0.0 <2.1.0`
+		part = hyphenReplace(re, part)
+		// `> 1.0` => `>1.0`
+		part = re["COMPARATORTRIM"].ReplaceAllString(part, "$1$2$3")
+		part = composerTildeCaretTrimRegex.ReplaceAllString(part, " $1")
+
+		var expanded []string
+		for _, c := range strings.FieldsFunc(part, func(r rune) bool { return r == ',' || r == ' ' }) {
+			if strings.HasPrefix(c, "~") {
+				v, err := parseShortVersion(c[1:])
+				if err != nil {
+					return nil, 0, err
+				}
+				core := c[1:]
+				if i := strings.IndexAny(core, "-+"); i != -1 {
+					core = core[:i]
+				}
+				expanded = append(expanded, replacePessimistic(re, v, strings.Count(core, ".")+1)...)
+				continue
+			}
+			if strings.HasPrefix(c, "^") || isComposerWildcard(c) {
+				expanded = append(expanded, strings.Fields(parseComparatorString(re, c))...)
+				continue
+			}
+			// Partial versions are padded, not expanded like x-ranges
+			op, vStr, err := splitComparatorVersion(c)
+			if err != nil {
+				return nil, 0, err
+			}
+			if parseComparator(op) == nil {
+				return nil, 0, fmt.Errorf("Invalid operator %q in %q", op, c)
+			}
+			v, err := parseShortVersion(vStr)
+			if err != nil {
+				return nil, 0, err
+			}
+			expanded = append(expanded, op+v.String())
+		}
+		for i, c := range expanded {
+			// Prereleases in constraints lower the minimum stability
+			if _, vStr, err := splitComparatorVersion(c); err == nil {
+				if v, err := Parse(vStr); err == nil && composerStability(v) < minStability {
+					minStability = composerStability(v)
+				}
+			}
+			// Like Composer, upper bounds exclude prereleases of the bound itself:
+			// `<1.1.0` => `<1.1.0-0`, so 1.1.0-beta does not match 1.0.*
+			if strings.HasPrefix(c, "<") && !strings.HasPrefix(c, "<=") && !strings.ContainsAny(c, "-+") {
+				expanded[i] = c + "-0"
+			}
+		}
+		if len(expanded) == 0 {
+			// A stability flag on its own accepts any version
+			expanded = []string{">=0.0.0-0"}
+		}
+		parts = append(parts, expanded)
+	}
+	return parts, minStability, nil
+}
+
+// isComposerWildcard reports whether the version of a constraint contains
+// a wildcard like "1.0.*" or "1.x".
+func isComposerWildcard(s string) bool {
+	if i := strings.IndexAny(s, "-+"); i != -1 {
+		s = s[:i]
+	}
+	return strings.ContainsAny(s, "*xX")
+}
+
+// composerStabilityIndex returns the index of a stability in composerStabilities
+// or -1 if it is unknown.
+func composerStabilityIndex(s string) int {
+	s = strings.ToLower(s)
+	for i, st := range composerStabilities {
+		if s == st {
+			return i
+		}
+	}
+	return -1
+}
+
+// composerStability derives the stability of a Version from its first
+// prerelease identifier as index in composerStabilities.
+// Unknown identifiers are considered dev.
+func composerStability(v Version) int {
+	if len(v.Pre) == 0 {
+		return composerStabilityIndex("stable")
+	}
+	match := composerStabilityRegex.FindStringSubmatch(strings.ToLower(v.Pre[0].String()))
+	if match == nil {
+		return composerStabilityIndex("dev")
+	}
+	switch match[1] {
+	case "rc":
+		return composerStabilityIndex("rc")
+	case "beta", "b":
+		return composerStabilityIndex("beta")
+	case "alpha", "a":
+		return composerStabilityIndex("alpha")
+	}
+	// Patch releases are stable
+	return composerStabilityIndex("stable")
+}
@@ -0,0 +1,163 @@
+package semver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseComposerRangeExpansion(t *testing.T) {
+	tests := []struct {
+		i         string
+		o         [][]string
+		stability string
+	}{
+		{"1.0.2", [][]string{{"1.0.2"}}, "stable"},
+		{">=1.0 <1.1 || >=1.2", [][]string{{">=1.0.0", "<1.1.0-0"}, {">=1.2.0"}}, "stable"},
+		{">=1.0,<1.1", [][]string{{">=1.0.0", "<1.1.0-0"}}, "stable"},
+		{">= 1.0, < 1.1 | >= 1.2", [][]string{{">=1.0.0", "<1.1.0-0"}, {">=1.2.0"}}, "stable"},
+		{"1.0 - 2.0", [][]string{{">=1.0.0", "<2.1.0-0"}}, "stable"},
+		{"1.0.*", [][]string{{">=1.0.0", "<1.1.0-0"}}, "stable"},
+		{"~1.2", [][]string{{">=1.2.0", "<2.0.0-0"}}, "stable"},
+		{"~1.2.3", [][]string{{">=1.2.3", "<1.3.0-0"}}, "stable"},
+		{"~ 1.2.3", [][]string{{">=1.2.3", "<1.3.0-0"}}, "stable"},
+		{"^1.2.3", [][]string{{">=1.2.3", "<2.0.0-0"}}, "stable"},
+		{"^0.3", [][]string{{">=0.3.0", "<0.4.0-0"}}, "stable"},
+		{"^1.2 || ~2.0", [][]string{{">=1.2.0", "<2.0.0-0"}, {">=2.0.0", "<3.0.0-0"}}, "stable"},
+		{"1.0.*@beta", [][]string{{">=1.0.0", "<1.1.0-0"}}, "beta"},
+		{"^1.2@RC || ~2.0@alpha", [][]string{{">=1.2.0", "<2.0.0-0"}, {">=2.0.0", "<3.0.0-0"}}, "alpha"},
+		{"@dev", [][]string{{">=0.0.0-0"}}, "dev"},
+		{">=1.0.0-beta2 <2.0", [][]string{{">=1.0.0-beta2", "<2.0.0-0"}}, "beta"},
+		{"1.0", [][]string{{"1.0.0"}}, "stable"},
+		{"=1.0", [][]string{{"=1.0.0"}}, "stable"},
+		{"<=1.0", [][]string{{"<=1.0.0"}}, "stable"},
+		{">1.0", [][]string{{">1.0.0"}}, "stable"},
+		{"!=1.0", [][]string{{"!=1.0.0"}}, "stable"},
+		{"1.x", [][]string{{">=1.0.0", "<2.0.0-0"}}, "stable"},
+		{"1.0 || @dev", [][]string{{"1.0.0"}, {">=0.0.0-0"}}, "dev"},
+		// Errors
+		{"", nil, ""},
+		{"1.0.*@foo", nil, ""},
+		{"dev-master", nil, ""},
+		{"~foo", nil, ""},
+		{"|", nil, ""},
+		{"||", nil, ""},
+		{"1.0 ||", nil, ""},
+		{"1.0 || || 2.0", nil, ""},
+		{"~>1.0", nil, ""},
+	}
+
+	for _, tc := range tests {
+		o, st, err := parseComposerRange(tc.i)
+		if err != nil {
+			if tc.o != nil {
+				t.Errorf("Invalid for case %q: Expected %q, got error %q", tc.i, tc.o, err)
+			}
+			continue
+		} else if tc.o == nil {
+			t.Errorf("Invalid for case %q: Expected error, got %q", tc.i, o)
+			continue
+		}
+		if !reflect.DeepEqual(tc.o, o) {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+		if composerStabilities[st] != tc.stability {
+			t.Errorf("Invalid stability for case %q: Expected %q, got: %q", tc.i, tc.stability, composerStabilities[st])
+		}
+	}
+}
+
+func TestParseComposerRange(t *testing.T) {
+	type tv struct {
+		v string
+		b bool
+	}
+	tests := []struct {
+		i string
+		t []tv
+	}{
+		{"^1.2 || ~2.0", []tv{
+			{"1.1.0", false},
+			{"1.2.0", true},
+			{"1.3.0-beta.1", false},
+			{"2.5.0", true},
+			{"3.0.0", false},
+		}},
+		{">=1.0 <1.1 || >=1.2", []tv{
+			{"1.0.5", true},
+			{"1.1.0", false},
+			{"1.2.0", true},
+		}},
+		{"1.0.*@beta", []tv{
+			{"1.0.1", true},
+			{"1.0.1-RC1", true},
+			{"1.0.1-beta.2", true},
+			{"1.0.1-alpha.2", false},
+			{"1.0.1-dev", false},
+			{"1.1.0-beta.1", false},
+		}},
+		{">=1.0.0-alpha2", []tv{
+			{"1.0.0-alpha3", true},
+			{"1.0.0-beta1", true},
+			{"1.1.0-dev", false},
+			{"1.1.0", true},
+		}},
+		{"^1.0@dev", []tv{
+			{"1.1.0-dev", true},
+			{"1.1.0-foo", true},
+			{"2.0.0-dev", false},
+		}},
+		{"^1.0", []tv{
+			{"1.1.0-p1", true},
+			{"1.1.0-foo", false},
+		}},
+		{"<=1.0", []tv{
+			{"0.9.0", true},
+			{"1.0.0", true},
+			{"1.0.5", false},
+		}},
+		{">1.0", []tv{
+			{"1.0.0", false},
+			{"1.0.5", true},
+			{"1.1.0", true},
+		}},
+		{"1.0", []tv{
+			{"1.0.0", true},
+			{"1.0.1", false},
+		}},
+		{"=1.0", []tv{
+			{"1.0.0", true},
+			{"1.0.1", false},
+		}},
+		{"!=1.0", []tv{
+			{"1.0.0", false},
+			{"1.0.1", true},
+			{"0.9.0", true},
+		}},
+	}
+
+	for _, tc := range tests {
+		r, err := ParseComposerRange(tc.i)
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		for _, tvc := range tc.t {
+			v := MustParse(tvc.v)
+			if res := r(v); res != tvc.b {
+				t.Errorf("Invalid for case %q matching %q: Expected %t, got: %t", tc.i, tvc.v, tvc.b, res)
+			}
+		}
+	}
+}
+
+func TestParseComposerRangeErrors(t *testing.T) {
+	tests := []string{"", "|", "||", "^", "1.0 ||"}
+	for _, tc := range tests {
+		if _, err := ParseComposerRange(tc); err == nil {
+			t.Errorf("Expected error for %q", tc)
+		} else if !strings.HasPrefix(err.Error(), "Could not parse Composer constraint") {
+			t.Errorf("Unexpected error for %q: %s", tc, err)
+		}
+	}
+}
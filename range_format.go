@@ -0,0 +1,88 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RangeFormatter formats a RangeSet in the range syntax of a package ecosystem.
+// An error is returned if the RangeSet can not be expressed in that syntax.
+type RangeFormatter interface {
+	FormatRange(rs RangeSet) (string, error)
+}
+
+// RangeFormatterFunc adapts a function to the RangeFormatter interface.
+type RangeFormatterFunc func(rs RangeSet) (string, error)
+
+// FormatRange calls f(rs).
+func (f RangeFormatterFunc) FormatRange(rs RangeSet) (string, error) {
+	return f(rs)
+}
+
+// RangeFormatters of the supported ecosystems.
+var (
+	NPMFormatter       RangeFormatter = RangeFormatterFunc(FormatNPMRange)
+	MavenFormatter     RangeFormatter = RangeFormatterFunc(FormatMavenRange)
+	NuGetFormatter     RangeFormatter = RangeFormatterFunc(FormatNuGetRange)
+	PEP440Formatter    RangeFormatter = RangeFormatterFunc(FormatPEP440Range)
+	RubyGemsFormatter  RangeFormatter = RangeFormatterFunc(FormatRubyGemsRange)
+	TerraformFormatter                = RubyGemsFormatter
+)
+
+// VersFormatter returns a RangeFormatter writing package URL "vers" ranges
+// using the given versioning scheme, see FormatVers.
+func VersFormatter(scheme string) RangeFormatter {
+	return RangeFormatterFunc(func(rs RangeSet) (string, error) {
+		return FormatVers(scheme, rs)
+	})
+}
+
+// FormatNPMRange formats a RangeSet in node-semver range notation,
+// like ">=1.0.0 <2.0.0 || 3.0.0".
+// Since node-semver has no "!=", exclusions are written as two sets:
+// "!=1.5.0" becomes "<1.5.0 || >1.5.0".
+func FormatNPMRange(rs RangeSet) (string, error) {
+	var sets []string
+	for _, set := range rs {
+		var bounds []Condition
+		var excluded []Version
+		for _, c := range set {
+			if c.Operator == "!=" {
+				excluded = append(excluded, c.Version)
+			} else {
+				bounds = append(bounds, c)
+			}
+		}
+		i, err := toInterval(bounds)
+		if err == errEmptyRange {
+			continue
+		} else if err != nil {
+			return "", fmt.Errorf("Could not format range %q as npm: %s", rs.String(), err)
+		}
+
+		sort.Sort(Versions(excluded))
+		for _, i := range splitIntervals([]interval{i}, excluded) {
+			sets = append(sets, formatNPMInterval(i))
+		}
+	}
+	if len(sets) == 0 {
+		return "", fmt.Errorf("Could not format range %q as npm: %s", rs.String(), errEmptyRange)
+	}
+	return strings.Join(sets, " || "), nil
+}
+
+// formatNPMInterval formats an interval as node-semver comparator set.
+func formatNPMInterval(i interval) string {
+	switch {
+	case i.exact():
+		return i.lower.Version.String()
+	case i.lower == nil && i.upper == nil:
+		return "*"
+	case i.lower == nil:
+		return i.upper.String()
+	case i.upper == nil:
+		return i.lower.String()
+	}
+	return i.lower.String() + " " + i.upper.String()
+}
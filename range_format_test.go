@@ -0,0 +1,98 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestFormatNPMRange(t *testing.T) {
+	tests := []struct {
+		i string
+		o string
+	}{
+		{">=1.0.0 <2.0.0", ">=1.0.0 <2.0.0"},
+		{"^1.2.3 || ~2.1.0", ">=1.2.3 <2.0.0 || >=2.1.0 <2.2.0"},
+		{"1.2.3", "1.2.3"},
+		{">=1.0.0 <=1.0.0", "1.0.0"},
+		{"*", ">=0.0.0"},
+		{">=1.0.0 <1.0.0 || <0.5.0", "<0.5.0"},
+		{"<1.0.0", "<1.0.0"},
+		{">=1.0.0 >1.2.0", ">1.2.0"},
+		{"!=1.5.0", "<1.5.0 || >1.5.0"},
+		{">=1.0.0 <2.0.0 !=1.5.0 !=1.2.0", ">=1.0.0 <1.2.0 || >1.2.0 <1.5.0 || >1.5.0 <2.0.0"},
+		{">=1.0.0 <2.0.0 !=1.0.0 !=3.0.0", ">1.0.0 <2.0.0"},
+		{">=1.0.0 <2.0.0 !=1.5.0 || >=3.0.0", ">=1.0.0 <1.5.0 || >1.5.0 <2.0.0 || >=3.0.0"},
+		{">2.0.0 <1.0.0 || >=3.0.0", ">=3.0.0"},
+		// Errors
+		{">2.0.0 <1.0.0", ""},
+		{"=1.0.0 !=1.0.0", ""},
+	}
+
+	for _, tc := range tests {
+		rs, err := ParseRangeSet(tc.i)
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		o, err := NPMFormatter.FormatRange(rs)
+		if err != nil {
+			if tc.o != "" {
+				t.Errorf("Invalid for case %q: Expected %q, got error %q", tc.i, tc.o, err)
+			}
+		} else if tc.o == "" {
+			t.Errorf("Invalid for case %q: Expected error, got %q", tc.i, o)
+		} else if o != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+	}
+}
+
+func TestRangeFormatters(t *testing.T) {
+	formatters := []struct {
+		name  string
+		f     RangeFormatter
+		parse func(string) (Range, error)
+	}{
+		{"npm", NPMFormatter, ParseRange},
+		{"Maven", MavenFormatter, ParseMavenRange},
+		{"NuGet", NuGetFormatter, ParseNuGetRange},
+		{"PEP 440", PEP440Formatter, ParsePEP440Range},
+		{"Terraform", TerraformFormatter, func(s string) (Range, error) {
+			return ParseRangeWithOptions(s, RangeOptions{Dialect: DialectTerraform})
+		}},
+		{"vers", VersFormatter("npm"), func(s string) (Range, error) {
+			_, rs, err := ParseVers(s)
+			if err != nil {
+				return nil, err
+			}
+			return rs.Range(), nil
+		}},
+	}
+	ranges := []string{">=1.0.0 <2.0.0", "1.2.3", "<=1.5.0", ">1.0.0-rc.1", "*"}
+	versions := []string{"0.1.0", "1.0.0-rc.1", "1.0.0-rc.2", "1.0.0", "1.2.3", "1.5.0", "1.9.9", "2.0.0", "3.0.0"}
+
+	for _, r := range ranges {
+		rs, err := ParseRangeSet(r)
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", r, err)
+			continue
+		}
+		expected := rs.Range()
+		for _, f := range formatters {
+			o, err := f.f.FormatRange(rs)
+			if err != nil {
+				t.Errorf("Invalid for case %q: Expected %s range, got error %q", r, f.name, err)
+				continue
+			}
+			pr, err := f.parse(o)
+			if err != nil {
+				t.Errorf("Invalid for case %q: Formatted %s range %q not parseable: %s", r, f.name, o, err)
+				continue
+			}
+			for _, v := range versions {
+				if expected(MustParse(v)) != pr(MustParse(v)) {
+					t.Errorf("Invalid for case %q: Formatted %s range %q differs for %q", r, f.name, o, v)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseMavenRange parses a Maven version range and returns a Range.
+// If the range could not be parsed an error is returned.
+//
+// Valid ranges are:
+//   - "1.0" soft requirement, x >= 1.0.0
+//   - "[1.0]" x == 1.0.0
+//   - "(,1.0]" x <= 1.0.0
+//   - "[1.2,1.3]" 1.2.0 <= x <= 1.3.0
+//   - "[1.0,2.0)" 1.0.0 <= x < 2.0.0
+//   - "[1.5,)" x >= 1.5.0
+//
+// Multiple ranges separated by comma are linked by logical OR:
+//   - "(,1.0],[1.2,)" x <= 1.0.0 or x >= 1.2.0
+//   - "(,1.1),(1.1,)" every version except 1.1.0
+//
+// Missing minor and patch numbers are filled up with 0. A qualifier like
+// "1.0-alpha-1" is used as prerelease, so it compares by semver rules
+// instead of the Maven qualifier order.
+func ParseMavenRange(s string) (Range, error) {
+	parts, err := parseMavenRange(s)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse Maven range %q: %s", s, err)
+	}
+	return buildRange(parts)
+}
+
+// FormatMavenRange formats a RangeSet in Maven version range notation,
+// like "[1.0.0,2.0.0),[3.0.0,)". Overlapping sets are merged.
+// A lower bound is always written as interval, since a bare version is
+// only a soft requirement in Maven.
+// An error is returned if the RangeSet uses "!=", which Maven does not support.
+func FormatMavenRange(rs RangeSet) (string, error) {
+	for _, set := range rs {
+		for _, c := range set {
+			if c.Operator == "!=" {
+				return "", fmt.Errorf("Could not format range %q as Maven: Condition %q not supported", rs.String(), c.String())
+			}
+		}
+	}
+	intervals, excluded, err := toIntervals(rs)
+	if err != nil {
+		return "", fmt.Errorf("Could not format range %q as Maven: %s", rs.String(), err)
+	}
+	if len(intervals) == 0 {
+		return "", fmt.Errorf("Could not format range %q as Maven: %s", rs.String(), errEmptyRange)
+	}
+
+	// Adjacent exclusive intervals like "(,1.0),(1.0,)" are kept apart
+	intervals = splitIntervals(intervals, excluded)
+	out := make([]string, 0, len(intervals))
+	for _, i := range intervals {
+		if i.upper == nil && (i.lower == nil || i.lower.Operator == ">=") {
+			lower := Version{}
+			if i.lower != nil {
+				lower = i.lower.Version
+			}
+			out = append(out, "["+lower.String()+",)")
+			continue
+		}
+		out = append(out, formatInterval(i))
+	}
+	return strings.Join(out, ","), nil
+}
+
+// parseMavenRange expands a Maven range into comparators.
+func parseMavenRange(s string) ([][]string, error) {
+	s = strings.Join(strings.Fields(s), "")
+	if len(s) == 0 {
+		return nil, errors.New("Range is empty")
+	}
+
+	// A version without brackets is a soft requirement
+	if s[0] != '[' && s[0] != '(' {
+		if strings.ContainsAny(s, "[](),") {
+			return nil, fmt.Errorf("Missing opening bracket in %q", s)
+		}
+		v, err := parseMavenVersion(s)
+		if err != nil {
+			return nil, err
+		}
+		return [][]string{{">=" + v.String()}}, nil
+	}
+
+	var parts [][]string
+	for len(s) > 0 {
+		end := strings.IndexAny(s, "])")
+		if end == -1 {
+			return nil, fmt.Errorf("Missing closing bracket in %q", s)
+		}
+		part, err := parseInterval(s[0], s[1:end], s[end], parseMavenVersion)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+
+		s = s[end+1:]
+		if len(s) == 0 {
+			break
+		}
+		if s[0] != ',' || len(s) == 1 {
+			return nil, fmt.Errorf("Expected comma separated range at %q", s)
+		}
+		s = s[1:]
+		if s[0] != '[' && s[0] != '(' {
+			return nil, fmt.Errorf("Missing opening bracket in %q", s)
+		}
+	}
+	return parts, nil
+}
+
+// parseInterval expands a single bracketed interval like "[1.0,2.0)" into
+// comparators. Maven and NuGet share this notation.
+func parseInterval(open byte, body string, closing byte, parseVersion func(string) (Version, error)) ([]string, error) {
+	interval := string(open) + body + string(closing)
+	if strings.ContainsAny(body, "[]()") {
+		return nil, fmt.Errorf("Nested brackets in %q", interval)
+	}
+
+	bounds := strings.Split(body, ",")
+	if len(bounds) == 1 {
+		if open != '[' || closing != ']' {
+			return nil, fmt.Errorf("Single version must be enclosed in [] in %q", interval)
+		}
+		v, err := parseVersion(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+		return []string{"=" + v.String()}, nil
+	}
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("Too many versions in %q", interval)
+	}
+
+	var out []string
+	var lower, upper Version
+	if len(bounds[0]) > 0 {
+		v, err := parseVersion(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+		op := ">"
+		if open == '[' {
+			op = ">="
+		}
+		lower = v
+		out = append(out, op+v.String())
+	}
+	if len(bounds[1]) > 0 {
+		v, err := parseVersion(bounds[1])
+		if err != nil {
+			return nil, err
+		}
+		op := "<"
+		if closing == ']' {
+			op = "<="
+		}
+		upper = v
+		out = append(out, op+v.String())
+	}
+	if len(out) == 0 {
+		// (,) does not restrict anything
+		return []string{">=0.0.0"}, nil
+	}
+	if len(out) == 2 {
+		if comp := lower.Compare(upper); comp > 0 {
+			return nil, fmt.Errorf("Lower bound is greater than upper bound in %q", interval)
+		} else if comp == 0 && (open == '(' || closing == ')') {
+			// (1.0,1.0) and [1.0,1.0) can not match any version
+			return nil, fmt.Errorf("Equal bounds require an inclusive range like [1.0] in %q", interval)
+		}
+	}
+	return out, nil
+}
+
+// parseMavenVersion parses a Maven version like "1", "1.2" or "1.2.3-beta-1"
+// into a Version. The qualifier becomes the prerelease.
+func parseMavenVersion(s string) (Version, error) {
+	numbers, qualifier := s, ""
+	if i := strings.IndexRune(s, '-'); i != -1 {
+		numbers, qualifier = s[:i], s[i+1:]
+	}
+
+	v, err := ParseTolerant(numbers)
+	if err != nil {
+		return Version{}, fmt.Errorf("Invalid Maven version %q: %s", s, err)
+	}
+
+	if len(qualifier) > 0 {
+		for _, id := range strings.FieldsFunc(qualifier, func(r rune) bool { return r == '-' || r == '.' }) {
+			pr, err := NewPRVersion(id)
+			if err != nil {
+				return Version{}, fmt.Errorf("Invalid Maven version %q: %s", s, err)
+			}
+			v.Pre = append(v.Pre, pr)
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,158 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseNuGetRange parses a NuGet version range and returns a Range.
+// If the range could not be parsed an error is returned.
+//
+// Valid ranges are:
+//   - "1.0" x >= 1.0.0
+//   - "(1.0,)" x > 1.0.0
+//   - "[1.0]" x == 1.0.0
+//   - "(,1.0]" x <= 1.0.0
+//   - "(,1.0)" x < 1.0.0
+//   - "[1.0,2.0]" 1.0.0 <= x <= 2.0.0
+//   - "(1.0,2.0)" 1.0.0 < x < 2.0.0
+//   - "[1.0,2.0)" 1.0.0 <= x < 2.0.0
+//
+// Floating versions are expanded like x-ranges:
+//   - "1.*" 1.0.0 <= x < 2.0.0
+//   - "1.2.*" 1.2.0 <= x < 1.3.0
+//   - "*" any version
+//
+// Missing minor and patch numbers are filled up with 0. Four-part versions
+// like "1.0.0.0" are accepted if the revision number is 0, since it can not
+// be represented as semver otherwise.
+func ParseNuGetRange(s string) (Range, error) {
+	rs, err := ParseNuGetRangeSet(s)
+	if err != nil {
+		return nil, err
+	}
+	return rs.Range(), nil
+}
+
+// ParseNuGetRangeSet parses a NuGet version range like ParseNuGetRange
+// and returns its RangeSet.
+func ParseNuGetRangeSet(s string) (RangeSet, error) {
+	part, err := parseNuGetRange(s)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse NuGet range %q: %s", s, err)
+	}
+	return buildRangeSet([][]string{part})
+}
+
+// parseNuGetRange expands a NuGet range into comparators.
+func parseNuGetRange(s string) ([]string, error) {
+	s = strings.Join(strings.Fields(s), "")
+	if len(s) == 0 {
+		return nil, errors.New("Range is empty")
+	}
+
+	if s[0] == '[' || s[0] == '(' {
+		closing := s[len(s)-1]
+		if len(s) < 2 || (closing != ']' && closing != ')') {
+			return nil, fmt.Errorf("Missing closing bracket in %q", s)
+		}
+		return parseInterval(s[0], s[1:len(s)-1], closing, parseNuGetVersion)
+	}
+
+	if strings.ContainsAny(s, "[](),") {
+		return nil, fmt.Errorf("Missing opening bracket in %q", s)
+	}
+	if strings.ContainsRune(s, '*') {
+		return parseNuGetFloatingRange(s)
+	}
+	v, err := parseNuGetVersion(s)
+	if err != nil {
+		return nil, err
+	}
+	return []string{">=" + v.String()}, nil
+}
+
+// parseNuGetVersion parses a NuGet version like "1.2", "1.2.3-beta" or
+// "1.2.3.0". A revision number other than 0 results in an error.
+func parseNuGetVersion(s string) (Version, error) {
+	core, suffix := s, ""
+	if i := strings.IndexAny(s, "-+"); i != -1 {
+		core, suffix = s[:i], s[i:]
+	}
+	if parts := strings.Split(core, "."); len(parts) == 4 {
+		revision, err := strconv.ParseUint(parts[3], 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("Invalid revision number in NuGet version %q", s)
+		}
+		if revision != 0 {
+			return Version{}, fmt.Errorf("Revision number of NuGet version %q can not be represented as semver", s)
+		}
+		core = strings.Join(parts[:3], ".")
+	} else if len(parts) > 4 {
+		return Version{}, fmt.Errorf("NuGet version %q has more than four parts", s)
+	}
+	return parseShortVersion(core + suffix)
+}
+
+// parseNuGetFloatingRange expands a floating version like "1.*" using the
+// x-range rules.
+func parseNuGetFloatingRange(s string) ([]string, error) {
+	re := getRegex()
+	if !(unicode.IsDigit(rune(s[0])) || s == "*") || !re["XRANGE"].MatchString(s) {
+		return nil, fmt.Errorf("Unsupported floating version %q", s)
+	}
+	return strings.Fields(replaceStars(re, replaceXRange(re, s))), nil
+}
+
+// FormatNuGetRange formats a RangeSet in NuGet version range notation.
+// An error is returned if the RangeSet can not be expressed as a single
+// NuGet interval, e.g. if it uses logical OR or "!=".
+func FormatNuGetRange(rs RangeSet) (string, error) {
+	if len(rs) != 1 {
+		return "", fmt.Errorf("Could not format range %q as NuGet: Exactly one interval required", rs.String())
+	}
+	i, err := toInterval(rs[0])
+	if err != nil {
+		return "", fmt.Errorf("Could not format range %q as NuGet: %s", rs.String(), err)
+	}
+	return formatInterval(i), nil
+}
+
+// formatInterval formats an interval in the bracket notation shared by
+// NuGet and Maven. A lone inclusive lower bound is written as bare version.
+func formatInterval(i interval) string {
+	if i.exact() {
+		return "[" + i.lower.Version.String() + "]"
+	}
+	if i.upper == nil {
+		if i.lower == nil {
+			return "0.0.0"
+		}
+		if i.lower.Operator == ">=" {
+			return i.lower.Version.String()
+		}
+	}
+
+	b := make([]byte, 0, 16)
+	if i.lower != nil && i.lower.Operator == ">=" {
+		b = append(b, '[')
+	} else {
+		b = append(b, '(')
+	}
+	if i.lower != nil {
+		b = append(b, i.lower.Version.String()...)
+	}
+	b = append(b, ',')
+	if i.upper != nil {
+		b = append(b, i.upper.Version.String()...)
+	}
+	if i.upper != nil && i.upper.Operator == "<=" {
+		b = append(b, ']')
+	} else {
+		b = append(b, ')')
+	}
+	return string(b)
+}
@@ -0,0 +1,148 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNuGetRangeExpansion(t *testing.T) {
+	tests := []struct {
+		i string
+		o []string
+	}{
+		{"1.0", []string{">=1.0.0"}},
+		{"1.0.0-beta.1", []string{">=1.0.0-beta.1"}},
+		{"(1.0,)", []string{">1.0.0"}},
+		{"[1.0]", []string{"=1.0.0"}},
+		{"(,1.0]", []string{"<=1.0.0"}},
+		{"(,1.0)", []string{"<1.0.0"}},
+		{"[1.0,2.0]", []string{">=1.0.0", "<=2.0.0"}},
+		{"(1.0,2.0)", []string{">1.0.0", "<2.0.0"}},
+		{"[1.0, 2.0)", []string{">=1.0.0", "<2.0.0"}},
+		{"1.*", []string{">=1.0.0", "<2.0.0"}},
+		{"1.2.*", []string{">=1.2.0", "<1.3.0"}},
+		{"*", []string{">=0.0.0"}},
+		{"1.0.0.0", []string{">=1.0.0"}},
+		{"[1.0.0.0,2.0)", []string{">=1.0.0", "<2.0.0"}},
+		{"[1.2.3.0-beta]", []string{"=1.2.3-beta"}},
+		// Errors
+		{"", nil},
+		{"[", nil},
+		{"[1.0", nil},
+		{"1.0]", nil},
+		{"(1.0)", nil},
+		{"[1.0,2.0),[3.0,)", nil},
+		{"1.0.0-*", nil},
+		{">1.*", nil},
+		{"[2.0,1.0]", nil},
+		{"(1.0,1.0)", nil},
+		{"1.0.0.1", nil},
+		{"[1.0.0.x,2.0)", nil},
+		{"1.0.0.0.0", nil},
+	}
+
+	for _, tc := range tests {
+		o, err := parseNuGetRange(tc.i)
+		if err != nil {
+			if tc.o != nil {
+				t.Errorf("Invalid for case %q: Expected %q, got error %q", tc.i, tc.o, err)
+			}
+		} else if tc.o == nil {
+			t.Errorf("Invalid for case %q: Expected error, got %q", tc.i, o)
+		} else if !reflect.DeepEqual(tc.o, o) {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+	}
+}
+
+func TestParseNuGetRange(t *testing.T) {
+	type tv struct {
+		v string
+		b bool
+	}
+	tests := []struct {
+		i string
+		t []tv
+	}{
+		{"1.0", []tv{
+			{"0.9.9", false},
+			{"1.0.0", true},
+			{"5.0.0", true},
+		}},
+		{"[1.0,2.0)", []tv{
+			{"0.9.0", false},
+			{"1.0.0", true},
+			{"1.9.9", true},
+			{"2.0.0", false},
+		}},
+		{"1.*", []tv{
+			{"0.9.0", false},
+			{"1.0.0", true},
+			{"1.9.9", true},
+			{"2.0.0", false},
+		}},
+	}
+
+	for _, tc := range tests {
+		r, err := ParseNuGetRange(tc.i)
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		for _, tvc := range tc.t {
+			v := MustParse(tvc.v)
+			if res := r(v); res != tvc.b {
+				t.Errorf("Invalid for case %q matching %q: Expected %t, got: %t", tc.i, tvc.v, tvc.b, res)
+			}
+		}
+	}
+}
+
+func TestFormatNuGetRange(t *testing.T) {
+	tests := []struct {
+		i string
+		o string
+	}{
+		{">=1.0.0", "1.0.0"},
+		{">1.0.0", "(1.0.0,)"},
+		{"=1.0.0", "[1.0.0]"},
+		{"<=1.0.0", "(,1.0.0]"},
+		{"<1.0.0", "(,1.0.0)"},
+		{">=1.0.0 <=2.0.0", "[1.0.0,2.0.0]"},
+		{">1.0.0 <2.0.0", "(1.0.0,2.0.0)"},
+		{"^1.2.3", "[1.2.3,2.0.0)"},
+		{"~1.2.3-beta.1", "[1.2.3-beta.1,1.3.0)"},
+		{">=1.0.0 >1.2.0 <3.0.0 <=2.0.0", "(1.2.0,2.0.0]"},
+		{">=1.0.0 <=1.0.0", "[1.0.0]"},
+		{"*", "0.0.0"},
+		// Errors
+		{">=1.0.0 || >=3.0.0", ""},
+		{">=1.0.0 !=1.5.0", ""},
+		{">2.0.0 <1.0.0", ""},
+		{">1.0.0 <=1.0.0", ""},
+	}
+
+	for _, tc := range tests {
+		rs, err := ParseRangeSetWithOptions(tc.i, RangeOptions{})
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		o, err := FormatNuGetRange(rs)
+		if err != nil {
+			if tc.o != "" {
+				t.Errorf("Invalid for case %q: Expected %q, got error %q", tc.i, tc.o, err)
+			}
+		} else if tc.o == "" {
+			t.Errorf("Invalid for case %q: Expected error, got %q", tc.i, o)
+		} else if o != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+
+		if tc.o != "" {
+			if _, err := ParseNuGetRange(o); err != nil {
+				t.Errorf("Invalid for case %q: Formatted range %q not parseable: %s", tc.i, o, err)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package semver
 
 import (
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -213,6 +214,49 @@ func parseRange(s string) []string {
 	return regexp.MustCompile("\\s+").Split(strings.Join(out, " "), -1)
 }
 
+// checkStrictRange returns an error if s contains any range sugar that
+// would be expanded by parseRange, naming the construct that was found.
+// Only plain comparators like `>=1.2.3` or `!=1.2.3` pass.
+func checkStrictRange(re map[string]*regexp.Regexp, s string) error {
+	s = strings.TrimSpace(s)
+	if re["HYPHENRANGE"].MatchString(s) {
+		return fmt.Errorf("Hyphen range not allowed in strict mode: %q", s)
+	}
+
+	s = re["COMPARATORTRIM"].ReplaceAllString(s, "$1$2$3")
+	s = re["TILDETRIM"].ReplaceAllString(s, "$1~")
+	s = re["CARETTRIM"].ReplaceAllString(s, "$1^")
+
+	for _, comp := range regexp.MustCompile("\\s+").Split(s, -1) {
+		if sugar := rangeSugar(re, comp); sugar != "" {
+			return fmt.Errorf("%s not allowed in strict mode: %q", sugar, comp)
+		}
+	}
+	return nil
+}
+
+// rangeSugar names the npm range construct used by a single comparator,
+// or returns an empty string for a plain comparator.
+func rangeSugar(re map[string]*regexp.Regexp, s string) string {
+	switch {
+	case re["CARET"].MatchString(s):
+		return "Caret range"
+	case re["TILDE"].MatchString(s):
+		return "Tilde range"
+	case re["XRANGE"].MatchString(s):
+		match := re["XRANGE"].FindStringSubmatch(s)
+		if isX(match[2]) {
+			return "Wildcard"
+		}
+		if isX(match[3]) || isX(match[4]) {
+			return "X-range"
+		}
+	case re["STAR"].MatchString(s):
+		return "Wildcard"
+	}
+	return ""
+}
+
 // comprised of xranges, tildes, stars, and gtlt's at this point.
 // already replaced the hyphen ranges
 // turn into a set of JUST comparators.
@@ -328,6 +372,38 @@ func replaceTilde(re map[string]*regexp.Regexp, s string) string {
 	return ret
 }
 
+// Pessimistic (RubyGems, Terraform) and compatible release (PEP 440)
+// constraints only allow the right-most given number to increase:
+// ~>2, ~>2.0 --> >=2.0.0 <3.0.0
+// ~>1.2 --> >=1.2.0 <2.0.0
+// ~>1.2.3 --> >=1.2.3 <1.3.0
+// ~>1.2.3-beta --> >=1.2.3-beta <1.3.0
+// segments is the number of version numbers given in the constraint.
+func replacePessimistic(re map[string]*regexp.Regexp, v Version, segments int) []string {
+	// ~>1.2.3 is >=1.2.3 with the upper bound of ~1.2
+	prefix := strconv.FormatUint(v.Major, 10)
+	if segments == 3 {
+		prefix += "." + strconv.FormatUint(v.Minor, 10)
+	}
+	tilde := strings.Fields(replaceTilde(re, "~"+prefix))
+	return []string{">=" + v.String(), tilde[1]}
+}
+
+// excludeUpperPrereleases makes exclusive upper bounds exclude the
+// prereleases of the bound, unless the bound is a prerelease itself:
+// <1.3.0 --> <1.3.0-0
+// <1.3.0-beta --> <1.3.0-beta
+func excludeUpperPrereleases(comps []string) []string {
+	out := make([]string, 0, len(comps))
+	for _, c := range comps {
+		if strings.HasPrefix(c, "<") && !strings.HasPrefix(c, "<=") && !strings.ContainsAny(c, "-+") {
+			c += "-0"
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
 // ^2, ^2.x, ^2.x.x --> >=2.0.0 <3.0.0
 // ^2.0, ^2.0.x --> >=2.0.0 <3.0.0
 // ^1.2, ^1.2.x --> >=1.2.0 <2.0.0
@@ -54,6 +54,48 @@ func TestTildeReplace(t *testing.T) {
 	}
 }
 
+func TestPessimisticReplace(t *testing.T) {
+	re := getRegex()
+	tests := []struct {
+		v        string
+		segments int
+		o        []string
+	}{
+		{"2.0.0", 1, []string{">=2.0.0", "<3.0.0"}},
+		{"2.0.0", 2, []string{">=2.0.0", "<3.0.0"}},
+		{"1.2.0", 2, []string{">=1.2.0", "<2.0.0"}},
+		{"1.2.3", 3, []string{">=1.2.3", "<1.3.0"}},
+		{"1.2.3-beta", 3, []string{">=1.2.3-beta", "<1.3.0"}},
+		{"0.2.0", 2, []string{">=0.2.0", "<1.0.0"}},
+	}
+
+	for _, tc := range tests {
+		o := replacePessimistic(re, MustParse(tc.v), tc.segments)
+		if !reflect.DeepEqual(tc.o, o) {
+			t.Errorf("Invalid for case %q (%d): Expected %q, got: %q", tc.v, tc.segments, tc.o, o)
+		}
+	}
+}
+
+func TestExcludeUpperPrereleases(t *testing.T) {
+	tests := []struct {
+		i []string
+		o []string
+	}{
+		{[]string{">=1.2.3", "<1.3.0"}, []string{">=1.2.3", "<1.3.0-0"}},
+		{[]string{"<1.3.0-beta", "<=2.0.0", ">1.0.0"}, []string{"<1.3.0-beta", "<=2.0.0", ">1.0.0"}},
+		{[]string{"<2.0.0+build"}, []string{"<2.0.0+build"}},
+		{nil, []string{}},
+	}
+
+	for _, tc := range tests {
+		o := excludeUpperPrereleases(tc.i)
+		if !reflect.DeepEqual(tc.o, o) {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+	}
+}
+
 func TestCaretReplace(t *testing.T) {
 	re := getRegex()
 	tests := []struct {
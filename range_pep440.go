@@ -0,0 +1,298 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pep440VersionRegex matches a PEP 440 version, see
+// https://peps.python.org/pep-0440/#appendix-b-parsing-version-strings-with-regular-expressions
+var pep440VersionRegex = regexp.MustCompile(`(?i)^v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?P<pre>[-_.]?(?P<pre_l>alpha|beta|preview|pre|rc|a|b|c)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?P<post>-[0-9]+|[-_.]?(?:post|rev|r)[-_.]?[0-9]*)?` +
+	`(?P<dev>[-_.]?dev[-_.]?[0-9]*)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// pep440Operators are the comparison operators of a PEP 440 specifier,
+// longest first.
+var pep440Operators = []string{"===", "~=", "==", "!=", "<=", ">=", "<", ">"}
+
+// ParsePEP440Range parses a PEP 440 version specifier set and returns a Range.
+// If the specifier set could not be parsed an error is returned.
+//
+// Valid specifiers are:
+//   - "~=1.4.2" compatible release, >=1.4.2 <1.5.0-0
+//   - "~=1.4" compatible release, >=1.4.0 <2.0.0-0
+//   - "==1.4.2", "===1.4.2"
+//   - "==1.4.*" prefix match, >=1.4.0-0 <1.5.0-0
+//   - "!=1.5.0", "!=1.5.*"
+//   - "<1.0", "<=1.0", ">1.0", ">=1.0"
+//
+// Specifiers separated by comma are linked by logical AND:
+//   - ">=1.0,<2.0,!=1.5.*"
+//
+// Missing minor and patch numbers are filled up with 0 and prereleases like
+// "1.0rc1" become "1.0.0-rc.1", which keeps the PEP 440 ordering of
+// a < b < rc. Epochs, post releases, development releases, local version
+// labels and versions with more than three release segments can not be
+// represented as semver and result in an error.
+// Prereleases are compared by semver rules, they are not excluded by default.
+// Like in PEP 440, "<1.0" does not match prereleases of 1.0 and becomes
+// "<1.0.0-0", while "<1.0rc1" stays "<1.0.0-rc.1". The same applies to the
+// upper bounds of compatible releases and prefix matches, so neither
+// "~=1.4.2" nor "==1.4.*" matches 1.5.0rc1.
+func ParsePEP440Range(s string) (Range, error) {
+	rs, err := ParsePEP440RangeSet(s)
+	if err != nil {
+		return nil, err
+	}
+	return rs.Range(), nil
+}
+
+// ParsePEP440RangeSet parses a PEP 440 version specifier set like
+// ParsePEP440Range and returns its RangeSet.
+func ParsePEP440RangeSet(s string) (RangeSet, error) {
+	parts, err := parsePEP440Range(s)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse PEP 440 specifier %q: %s", s, err)
+	}
+	return buildRangeSet(parts)
+}
+
+// FormatPEP440Range formats a RangeSet as PEP 440 version specifier set,
+// like ">=1.0.0,<2.0.0,!=1.5.0".
+// An error is returned if the RangeSet can not be expressed as a single
+// interval, since PEP 440 has no logical OR, or if a version can not be
+// represented in PEP 440. Prereleases like "1.0.0-rc.1" become "1.0.0rc1",
+// an upper bound "<2.0.0-0" becomes "<2.0.0" and a lower bound ">=1.4.0-0"
+// becomes ">=1.4.0a0".
+func FormatPEP440Range(rs RangeSet) (string, error) {
+	conds, err := intervalConditions(rs)
+	if err != nil {
+		return "", fmt.Errorf("Could not format range %q as PEP 440: %s", rs.String(), err)
+	}
+	out := make([]string, 0, len(conds))
+	for _, c := range conds {
+		if isFirstPrerelease(c.Version) {
+			switch c.Operator {
+			case "<":
+				// <V already excludes the prereleases of V
+				c.Version.Pre = nil
+			case ">=":
+				// a0 is the lowest PEP 440 prerelease
+				c.Version.Pre = []PRVersion{{VersionStr: "a"}, {VersionNum: 0, IsNum: true}}
+			}
+		}
+		vStr, err := formatPEP440Version(c.Version)
+		if err != nil {
+			return "", fmt.Errorf("Could not format range %q as PEP 440: %s", rs.String(), err)
+		}
+		op := c.Operator
+		if op == "=" {
+			op = "=="
+		}
+		out = append(out, op+vStr)
+	}
+	return strings.Join(out, ","), nil
+}
+
+// isFirstPrerelease reports whether v is the lowest prerelease "X.Y.Z-0".
+func isFirstPrerelease(v Version) bool {
+	return len(v.Pre) == 1 && v.Pre[0].IsNum && v.Pre[0].VersionNum == 0 && len(v.Build) == 0
+}
+
+// formatPEP440Version formats a Version as PEP 440 version.
+// Only prereleases of the form "a.N", "b.N" or "rc.N" can be represented.
+func formatPEP440Version(v Version) (string, error) {
+	if len(v.Build) > 0 {
+		return "", fmt.Errorf("Build metadata in %q can not be represented in PEP 440", v.String())
+	}
+	s := strconv.FormatUint(v.Major, 10) + "." + strconv.FormatUint(v.Minor, 10) + "." + strconv.FormatUint(v.Patch, 10)
+	if len(v.Pre) == 0 {
+		return s, nil
+	}
+	if len(v.Pre) != 2 || v.Pre[0].IsNum || !v.Pre[1].IsNum {
+		return "", fmt.Errorf("Prerelease in %q can not be represented in PEP 440", v.String())
+	}
+	label := ""
+	switch strings.ToLower(v.Pre[0].VersionStr) {
+	case "a", "alpha":
+		label = "a"
+	case "b", "beta":
+		label = "b"
+	case "rc", "c":
+		label = "rc"
+	default:
+		return "", fmt.Errorf("Prerelease in %q can not be represented in PEP 440", v.String())
+	}
+	return s + label + v.Pre[1].String(), nil
+}
+
+// parsePEP440Range expands a PEP 440 specifier set into comparators.
+func parsePEP440Range(s string) ([][]string, error) {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil, errors.New("Specifier is empty")
+	}
+
+	re := getRegex()
+	parts := [][]string{{}}
+	for _, spec := range strings.Split(s, ",") {
+		expanded, err := parsePEP440Specifier(re, strings.Join(strings.Fields(spec), ""))
+		if err != nil {
+			return nil, err
+		}
+		parts = andParts(parts, expanded)
+	}
+	return parts, nil
+}
+
+// parsePEP440Specifier expands a single specifier like "~=1.4.2" into comparators.
+func parsePEP440Specifier(re map[string]*regexp.Regexp, s string) ([][]string, error) {
+	op := ""
+	for _, o := range pep440Operators {
+		if strings.HasPrefix(s, o) {
+			op = o
+			break
+		}
+	}
+	if op == "" {
+		return nil, fmt.Errorf("Missing operator in %q", s)
+	}
+	vStr := s[len(op):]
+
+	if strings.HasSuffix(vStr, ".*") {
+		if op != "==" && op != "!=" {
+			return nil, fmt.Errorf("Prefix match is only allowed with == and != in %q", s)
+		}
+		v, segments, err := parsePEP440Version(strings.TrimSuffix(vStr, ".*"))
+		if err != nil {
+			return nil, err
+		}
+		if len(v.Pre) > 0 {
+			return nil, fmt.Errorf("Prefix match with prerelease in %q", s)
+		}
+		if segments == 3 {
+			// Without a fourth segment only the version itself matches
+			return [][]string{{strings.TrimPrefix(op, "=") + v.String()}}, nil
+		}
+		prefix := strconv.FormatUint(v.Major, 10) + ".x"
+		if segments == 2 {
+			prefix = strconv.FormatUint(v.Major, 10) + "." + strconv.FormatUint(v.Minor, 10) + ".x"
+		}
+		// ==1.4.* is the x-range 1.4.x including its prereleases, != negates it
+		xr := strings.Fields(replaceXRange(re, prefix))
+		lower, upper := strings.TrimPrefix(xr[0], ">=")+"-0", strings.TrimPrefix(xr[1], "<")+"-0"
+		if op == "==" {
+			return [][]string{{">=" + lower, "<" + upper}}, nil
+		}
+		return [][]string{{"<" + lower}, {">=" + upper}}, nil
+	}
+
+	if op == "===" {
+		v, err := Parse(vStr)
+		if err != nil {
+			return nil, fmt.Errorf("Arbitrary equality requires a semver version in %q: %s", s, err)
+		}
+		return [][]string{{"=" + v.String()}}, nil
+	}
+
+	v, segments, err := parsePEP440Version(vStr)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "~=":
+		if segments < 2 {
+			return nil, fmt.Errorf("Compatible release requires at least two release segments in %q", s)
+		}
+		return [][]string{excludeUpperPrereleases(replacePessimistic(re, v, segments))}, nil
+	case "==":
+		return [][]string{{"=" + v.String()}}, nil
+	case "<":
+		// <V excludes the prereleases of V unless V is a prerelease itself
+		if len(v.Pre) == 0 {
+			return [][]string{{"<" + v.String() + "-0"}}, nil
+		}
+	}
+	return [][]string{{op + v.String()}}, nil
+}
+
+// parsePEP440Version parses a PEP 440 version into a Version and returns
+// the number of release segments found.
+func parsePEP440Version(s string) (Version, int, error) {
+	match := pep440VersionRegex.FindStringSubmatch(s)
+	if match == nil {
+		return Version{}, 0, fmt.Errorf("Invalid PEP 440 version %q", s)
+	}
+	group := func(name string) string {
+		for i, n := range pep440VersionRegex.SubexpNames() {
+			if n == name {
+				return match[i]
+			}
+		}
+		return ""
+	}
+
+	if epoch := group("epoch"); len(epoch) > 0 && strings.Trim(epoch, "0") != "" {
+		return Version{}, 0, fmt.Errorf("Epoch in %q can not be represented as semver", s)
+	}
+	if len(group("post")) > 0 {
+		return Version{}, 0, fmt.Errorf("Post release %q can not be represented as semver", s)
+	}
+	if len(group("dev")) > 0 {
+		return Version{}, 0, fmt.Errorf("Development release %q can not be represented as semver", s)
+	}
+	if len(group("local")) > 0 {
+		return Version{}, 0, fmt.Errorf("Local version label in %q can not be represented as semver", s)
+	}
+
+	release := strings.Split(group("release"), ".")
+	if len(release) > 3 {
+		return Version{}, 0, fmt.Errorf("More than three release segments in %q can not be represented as semver", s)
+	}
+	var nums [3]uint64
+	for i, r := range release {
+		n, err := strconv.ParseUint(r, 10, 64)
+		if err != nil {
+			return Version{}, 0, fmt.Errorf("Invalid release segment %q in %q: %s", r, s, err)
+		}
+		nums[i] = n
+	}
+	v := Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}
+
+	if len(group("pre")) > 0 {
+		label := "rc"
+		switch strings.ToLower(group("pre_l")) {
+		case "a", "alpha":
+			label = "a"
+		case "b", "beta":
+			label = "b"
+		}
+		n, err := strconv.ParseUint("0"+group("pre_n"), 10, 64)
+		if err != nil {
+			return Version{}, 0, fmt.Errorf("Invalid prerelease number in %q: %s", s, err)
+		}
+		v.Pre = []PRVersion{{VersionStr: label}, {VersionNum: n, IsNum: true}}
+	}
+	return v, len(release), nil
+}
+
+// andParts links two expanded ranges by logical AND,
+// distributing the OR-ed sets of both sides.
+func andParts(a, b [][]string) [][]string {
+	var out [][]string
+	for _, x := range a {
+		for _, y := range b {
+			set := make([]string, 0, len(x)+len(y))
+			set = append(set, x...)
+			out = append(out, append(set, y...))
+		}
+	}
+	return out
+}
@@ -0,0 +1,184 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePEP440RangeExpansion(t *testing.T) {
+	tests := []struct {
+		i string
+		o [][]string
+	}{
+		{"~=1.4.2", [][]string{{">=1.4.2", "<1.5.0-0"}}},
+		{"~=1.4", [][]string{{">=1.4.0", "<2.0.0-0"}}},
+		{"~= 2.2.0rc1", [][]string{{">=2.2.0-rc.1", "<2.3.0-0"}}},
+		{"==1.4.2", [][]string{{"=1.4.2"}}},
+		{"==1.4", [][]string{{"=1.4.0"}}},
+		{"===1.4.2", [][]string{{"=1.4.2"}}},
+		{"==1.4.*", [][]string{{">=1.4.0-0", "<1.5.0-0"}}},
+		{"==1.*", [][]string{{">=1.0.0-0", "<2.0.0-0"}}},
+		{"==1.4.2.*", [][]string{{"=1.4.2"}}},
+		{"!=1.5.0", [][]string{{"!=1.5.0"}}},
+		{"!=1.5.*", [][]string{{"<1.5.0-0"}, {">=1.6.0-0"}}},
+		{">=1.0,<2.0", [][]string{{">=1.0.0", "<2.0.0-0"}}},
+		{">= 1.0, < 2.0, != 1.5.*", [][]string{{">=1.0.0", "<2.0.0-0", "<1.5.0-0"}, {">=1.0.0", "<2.0.0-0", ">=1.6.0-0"}}},
+		{"<1.0rc1", [][]string{{"<1.0.0-rc.1"}}},
+		{">1.0a1", [][]string{{">1.0.0-a.1"}}},
+		{">1.0.0-alpha.1", [][]string{{">1.0.0-a.1"}}},
+		{">1.0b", [][]string{{">1.0.0-b.0"}}},
+		{">1.0c2", [][]string{{">1.0.0-rc.2"}}},
+		{">1.0.0.preview3", [][]string{{">1.0.0-rc.3"}}},
+		{"<=V1.0", [][]string{{"<=1.0.0"}}},
+		{"==0!1.0", [][]string{{"=1.0.0"}}},
+		// Errors
+		{"", nil},
+		{"1.0", nil},
+		{"~=1", nil},
+		{">=1.*", nil},
+		{"==1.0rc1.*", nil},
+		{"===1.0", nil},
+		{"==1!1.0", nil},
+		{"==1.0.post1", nil},
+		{"==1.0-1", nil},
+		{"==1.0.dev0", nil},
+		{"==1.0+ubuntu1", nil},
+		{"==1.0.0.1", nil},
+		{"==foo", nil},
+		{">=1.0,", nil},
+	}
+
+	for _, tc := range tests {
+		o, err := parsePEP440Range(tc.i)
+		if err != nil {
+			if tc.o != nil {
+				t.Errorf("Invalid for case %q: Expected %q, got error %q", tc.i, tc.o, err)
+			}
+		} else if tc.o == nil {
+			t.Errorf("Invalid for case %q: Expected error, got %q", tc.i, o)
+		} else if !reflect.DeepEqual(tc.o, o) {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+	}
+}
+
+func TestParsePEP440Range(t *testing.T) {
+	type tv struct {
+		v string
+		b bool
+	}
+	tests := []struct {
+		i string
+		t []tv
+	}{
+		{"~=1.4.2", []tv{
+			{"1.4.1", false},
+			{"1.4.2", true},
+			{"1.4.9", true},
+			{"1.5.0-rc.1", false},
+			{"1.5.0", false},
+		}},
+		{"==1.4.*", []tv{
+			{"1.4.0-rc.1", true},
+			{"1.4.0", true},
+			{"1.4.9", true},
+			{"1.5.0-rc.1", false},
+			{"1.5.0", false},
+		}},
+		{"!=1.5.*", []tv{
+			{"1.4.9", true},
+			{"1.5.0-rc.1", false},
+			{"1.5.3", false},
+			{"1.6.0-rc.1", true},
+			{"1.6.0", true},
+		}},
+		{">=1.0,<2.0,!=1.5.*", []tv{
+			{"0.9.0", false},
+			{"1.0.0", true},
+			{"1.4.9", true},
+			{"1.5.0", false},
+			{"1.5.3", false},
+			{"1.6.0", true},
+			{"2.0.0", false},
+		}},
+		{">=1.0a1,<1.0", []tv{
+			{"1.0.0-a.1", false},
+			{"1.0.0-b.0", false},
+			{"1.0.0-rc.2", false},
+			{"1.0.0", false},
+			{"0.9.0", false},
+		}},
+		{"<1.0", []tv{
+			{"0.9.0", true},
+			{"0.9.0-rc.1", true},
+			{"1.0.0-a.1", false},
+			{"1.0.0-rc.2", false},
+			{"1.0.0", false},
+		}},
+		{"<1.0rc2", []tv{
+			{"1.0.0-rc.1", true},
+			{"1.0.0-rc.2", false},
+		}},
+	}
+
+	for _, tc := range tests {
+		r, err := ParsePEP440Range(tc.i)
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		for _, tvc := range tc.t {
+			v := MustParse(tvc.v)
+			if res := r(v); res != tvc.b {
+				t.Errorf("Invalid for case %q matching %q: Expected %t, got: %t", tc.i, tvc.v, tvc.b, res)
+			}
+		}
+	}
+}
+
+func TestFormatPEP440Range(t *testing.T) {
+	tests := []struct {
+		i string
+		o string
+	}{
+		{">=1.0.0 <2.0.0", ">=1.0.0,<2.0.0"},
+		{">=1.0.0 <2.0.0 !=1.5.0", ">=1.0.0,<2.0.0,!=1.5.0"},
+		{"<1.0.0 || >1.0.0", "!=1.0.0"},
+		{"=1.2.3", "==1.2.3"},
+		{">=1.0.0-rc.1", ">=1.0.0rc1"},
+		{"<2.0.0-alpha.2", "<2.0.0a2"},
+		{">=1.0.0 <2.0.0-0", ">=1.0.0,<2.0.0"},
+		{">=1.4.0-0 <1.5.0-0", ">=1.4.0a0,<1.5.0"},
+		{"*", ">=0.0.0"},
+		// Errors
+		{">=1.0.0 <2.0.0 || >=3.0.0", ""},
+		{">=1.0.0-beta", ""},
+		{">=1.0.0-1.2", ""},
+		{">=1.0.0-dev.1", ""},
+		{">=1.0.0+build", ""},
+	}
+
+	for _, tc := range tests {
+		rs, err := ParseRangeSet(tc.i)
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		o, err := FormatPEP440Range(rs)
+		if err != nil {
+			if tc.o != "" {
+				t.Errorf("Invalid for case %q: Expected %q, got error %q", tc.i, tc.o, err)
+			}
+		} else if tc.o == "" {
+			t.Errorf("Invalid for case %q: Expected error, got %q", tc.i, o)
+		} else if o != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+
+		if tc.o != "" {
+			if _, err := ParsePEP440Range(o); err != nil {
+				t.Errorf("Invalid for case %q: Formatted specifier %q not parseable: %s", tc.i, o, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// rubyGemsOperators are the operators of a RubyGems or Terraform
+// constraint, longest first.
+var rubyGemsOperators = []string{"~>", "!=", ">=", "<=", "=", ">", "<"}
+
+// FormatRubyGemsRange formats a RangeSet as comma separated RubyGems or
+// Terraform constraints, like ">= 1.0.0, < 2.0.0, != 1.5.0".
+// An error is returned if the RangeSet can not be expressed as a single
+// interval, since neither supports logical OR.
+func FormatRubyGemsRange(rs RangeSet) (string, error) {
+	conds, err := intervalConditions(rs)
+	if err != nil {
+		return "", fmt.Errorf("Could not format range %q as RubyGems: %s", rs.String(), err)
+	}
+	out := make([]string, 0, len(conds))
+	for _, c := range conds {
+		out = append(out, c.Operator+" "+c.Version.String())
+	}
+	return strings.Join(out, ", "), nil
+}
+
+// parseRubyGemsRange expands comma separated RubyGems or Terraform
+// constraints into comparators.
+func parseRubyGemsRange(s string, strict bool) ([][]string, error) {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil, errors.New("Range is empty")
+	}
+
+	re := getRegex()
+	var out []string
+	for _, c := range strings.Split(s, ",") {
+		c = strings.TrimSpace(c)
+		if len(c) == 0 {
+			return nil, errors.New("Empty constraint")
+		}
+
+		op := ""
+		for _, o := range rubyGemsOperators {
+			if strings.HasPrefix(c, o) {
+				op = o
+				break
+			}
+		}
+		vStr := strings.TrimSpace(c[len(op):])
+		if strings.IndexFunc(vStr, unicode.IsSpace) != -1 {
+			return nil, fmt.Errorf("Invalid constraint %q", c)
+		}
+		v, err := parseShortVersion(vStr)
+		if err != nil {
+			return nil, err
+		}
+
+		if op != "~>" {
+			out = append(out, normalizeOperator(op)+v.String())
+			continue
+		}
+		if strict {
+			return nil, fmt.Errorf("Pessimistic constraint not allowed in strict mode: %q", c)
+		}
+		core := vStr
+		if i := strings.IndexAny(core, "-+"); i != -1 {
+			core = core[:i]
+		}
+		// The upper bound excludes its prereleases: `~> 1.2` => `>=1.2.0 <2.0.0-0`
+		out = append(out, excludeUpperPrereleases(replacePessimistic(re, v, strings.Count(core, ".")+1))...)
+	}
+	return [][]string{out}, nil
+}
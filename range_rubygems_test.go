@@ -0,0 +1,145 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRubyGemsRangeExpansion(t *testing.T) {
+	tests := []struct {
+		i string
+		o [][]string
+	}{
+		{"~> 1", [][]string{{">=1.0.0", "<2.0.0-0"}}},
+		{"~> 1.2", [][]string{{">=1.2.0", "<2.0.0-0"}}},
+		{"~>1.2.3", [][]string{{">=1.2.3", "<1.3.0-0"}}},
+		{"~> 0.2", [][]string{{">=0.2.0", "<1.0.0-0"}}},
+		{"~> 1.2.0-beta", [][]string{{">=1.2.0-beta", "<1.3.0-0"}}},
+		{"1.2.3", [][]string{{"=1.2.3"}}},
+		{"= 1.2.3", [][]string{{"=1.2.3"}}},
+		{">= 1.2, < 3.0, != 2.1.0", [][]string{{">=1.2.0", "<3.0.0", "!=2.1.0"}}},
+		{"~> 1.2, >= 1.2.5", [][]string{{">=1.2.0", "<2.0.0-0", ">=1.2.5"}}},
+		// Errors
+		{"", nil},
+		{"~>", nil},
+		{">= 1.0 <2.0", nil},
+		{">= 1.0 || < 0.5", nil},
+		{"^1.2", nil},
+		{"= 1.0 - 2.0", nil},
+		{">= 1.0,", nil},
+		{"~> 1.0, , < 2.0", nil},
+	}
+
+	for _, tc := range tests {
+		o, err := parseRubyGemsRange(tc.i, false)
+		if err != nil {
+			if tc.o != nil {
+				t.Errorf("Invalid for case %q: Expected %q, got error %q", tc.i, tc.o, err)
+			}
+		} else if tc.o == nil {
+			t.Errorf("Invalid for case %q: Expected error, got %q", tc.i, o)
+		} else if !reflect.DeepEqual(tc.o, o) {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+	}
+
+	if _, err := parseRubyGemsRange(">= 1.0,", false); err == nil || err.Error() != "Empty constraint" {
+		t.Errorf("Expected empty constraint error, got %v", err)
+	}
+}
+
+func TestParseRangeRubyGemsDialect(t *testing.T) {
+	type tv struct {
+		v string
+		b bool
+	}
+	tests := []struct {
+		i string
+		t []tv
+	}{
+		{"~> 1.2", []tv{
+			{"1.1.9", false},
+			{"1.2.0", true},
+			{"1.9.0", true},
+			{"2.0.0-beta", false},
+			{"2.0.0", false},
+		}},
+		{"~> 1.0.4", []tv{
+			{"1.0.3", false},
+			{"1.0.4", true},
+			{"1.0.10", true},
+			{"1.1.0", false},
+		}},
+		{">= 1.2.0, < 2.0.0, != 1.5.0", []tv{
+			{"1.1.9", false},
+			{"1.2.0", true},
+			{"1.5.0", false},
+			{"1.9.0", true},
+			{"2.0.0", false},
+		}},
+	}
+
+	for _, tc := range tests {
+		r, err := ParseRangeWithOptions(tc.i, RangeOptions{Dialect: DialectTerraform})
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		for _, tvc := range tc.t {
+			v := MustParse(tvc.v)
+			if res := r(v); res != tvc.b {
+				t.Errorf("Invalid for case %q matching %q: Expected %t, got: %t", tc.i, tvc.v, tvc.b, res)
+			}
+		}
+	}
+
+	if _, err := ParseRangeWithOptions("~> 1.2", RangeOptions{Dialect: DialectRubyGems, Strict: true}); err == nil {
+		t.Errorf("Expected error for pessimistic constraint in strict mode, got none")
+	}
+	if _, err := ParseRangeWithOptions(">= 1.2, < 2.0", RangeOptions{Dialect: DialectRubyGems, Strict: true}); err != nil {
+		t.Errorf("Unexpected error in strict mode: %s", err)
+	}
+	if _, err := ParseRangeWithOptions(">=1.2.0", RangeOptions{Dialect: RangeDialect(-1)}); err == nil {
+		t.Errorf("Expected error for unknown dialect, got none")
+	}
+}
+
+func TestFormatRubyGemsRange(t *testing.T) {
+	tests := []struct {
+		i string
+		o string
+	}{
+		{">=1.0.0 <2.0.0", ">= 1.0.0, < 2.0.0"},
+		{">=1.0.0 <2.0.0 !=1.5.0", ">= 1.0.0, < 2.0.0, != 1.5.0"},
+		{"=1.2.3", "= 1.2.3"},
+		{"~1.2.3-beta.1", ">= 1.2.3-beta.1, < 1.3.0"},
+		{"*", ">= 0.0.0"},
+		// Errors
+		{">=1.0.0 <2.0.0 || >=3.0.0", ""},
+		{">2.0.0 <1.0.0", ""},
+	}
+
+	for _, tc := range tests {
+		rs, err := ParseRangeSet(tc.i)
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		o, err := FormatRubyGemsRange(rs)
+		if err != nil {
+			if tc.o != "" {
+				t.Errorf("Invalid for case %q: Expected %q, got error %q", tc.i, tc.o, err)
+			}
+		} else if tc.o == "" {
+			t.Errorf("Invalid for case %q: Expected error, got %q", tc.i, o)
+		} else if o != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+
+		if tc.o != "" {
+			if _, err := ParseRangeWithOptions(o, RangeOptions{Dialect: DialectRubyGems}); err != nil {
+				t.Errorf("Invalid for case %q: Formatted constraint %q not parseable: %s", tc.i, o, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Condition is a single operator and version pair of a range, like ">=1.2.3".
+// Operator is one of "=", "!=", ">", ">=", "<" and "<=", the aliases "",
+// "==" and "!" are accepted as well. A Condition with any other operator
+// matches no version, see RangeSet.Validate.
+type Condition struct {
+	Operator string
+	Version  Version
+}
+
+// Condition to string
+func (c Condition) String() string {
+	return c.Operator + c.Version.String()
+}
+
+// rangeFunc creates a Range from the given Condition.
+// An unknown operator matches no version.
+func (c Condition) rangeFunc() Range {
+	comp := parseComparator(c.Operator)
+	if comp == nil {
+		return func(Version) bool { return false }
+	}
+	vr := versionRange{
+		v: c.Version,
+		c: comp,
+	}
+	return vr.rangeFunc()
+}
+
+// RangeSet is the structured form of a Range.
+// Conditions inside a set are linked by logical AND, the sets themselves
+// are linked by logical OR. So ">=1.0.0 <2.0.0 || >=3.0.0" consists of
+// the sets [">=1.0.0", "<2.0.0"] and [">=3.0.0"].
+type RangeSet [][]Condition
+
+// Range creates a Range checking the conditions of the RangeSet.
+// An empty RangeSet matches no version, an empty set of conditions
+// matches any version.
+func (rs RangeSet) Range() Range {
+	if len(rs) == 0 {
+		return func(Version) bool { return false }
+	}
+
+	var orFn Range
+	for _, set := range rs {
+		andFn := Range(func(Version) bool { return true })
+		for i, c := range set {
+			rf := c.rangeFunc()
+
+			// Set function
+			if i == 0 {
+				andFn = rf
+			} else { // Combine with existing function
+				andFn = andFn.AND(rf)
+			}
+		}
+		if orFn == nil {
+			orFn = andFn
+		} else {
+			orFn = orFn.OR(andFn)
+		}
+	}
+	return orFn
+}
+
+// Validate returns an error if a Condition of the RangeSet has an
+// unknown operator.
+func (rs RangeSet) Validate() error {
+	for _, set := range rs {
+		for _, c := range set {
+			if parseComparator(c.Operator) == nil {
+				return fmt.Errorf("Invalid operator %q in condition %q", c.Operator, c.String())
+			}
+		}
+	}
+	return nil
+}
+
+// RangeSet to string, as accepted by ParseRange
+func (rs RangeSet) String() string {
+	sets := make([]string, 0, len(rs))
+	for _, set := range rs {
+		conds := make([]string, 0, len(set))
+		for _, c := range set {
+			conds = append(conds, c.String())
+		}
+		sets = append(sets, strings.Join(conds, " "))
+	}
+	return strings.Join(sets, " || ")
+}
+
+// buildRangeSet builds a RangeSet from already expanded comparators.
+// The outer slice is linked by logical OR, the inner slices by logical AND.
+func buildRangeSet(expandedParts [][]string) (RangeSet, error) {
+	var rs RangeSet
+	for _, p := range expandedParts {
+		var set []Condition
+		for _, ap := range p {
+			opStr, vStr, err := splitComparatorVersion(ap)
+			if err != nil {
+				return nil, err
+			}
+			vr, err := buildVersionRange(opStr, vStr)
+			if err != nil {
+				return nil, fmt.Errorf("Could not parse Range %q: %s", ap, err)
+			}
+			set = append(set, Condition{
+				Operator: normalizeOperator(opStr),
+				Version:  vr.v,
+			})
+		}
+		rs = append(rs, set)
+	}
+	return rs, nil
+}
+
+// normalizeOperator maps the aliases accepted by parseComparator
+// to the operators used by Condition.
+func normalizeOperator(s string) string {
+	switch s {
+	case "", "==":
+		return "="
+	case "!":
+		return "!="
+	}
+	return s
+}
+
+// errEmptyRange is returned by toInterval if no version can match.
+var errEmptyRange = errors.New("Range does not match any version")
+
+// interval is a set of conditions reduced to a lower and an upper bound.
+// A missing bound is nil.
+type interval struct {
+	lower *Condition
+	upper *Condition
+}
+
+// exact returns true if the interval contains a single version only.
+func (i interval) exact() bool {
+	return i.lower != nil && i.upper != nil &&
+		i.lower.Operator == ">=" && i.upper.Operator == "<=" &&
+		i.lower.Version.EQ(i.upper.Version)
+}
+
+// toInterval reduces conditions linked by logical AND to an interval,
+// keeping the tightest lower and upper bound.
+// Conditions using "!=" can not be expressed as an interval.
+func toInterval(set []Condition) (interval, error) {
+	var i interval
+	for _, c := range set {
+		switch c.Operator {
+		case ">", ">=":
+			i.lower = tighterLower(i.lower, c)
+		case "<", "<=":
+			i.upper = tighterUpper(i.upper, c)
+		case "=":
+			i.lower = tighterLower(i.lower, Condition{">=", c.Version})
+			i.upper = tighterUpper(i.upper, Condition{"<=", c.Version})
+		default:
+			return interval{}, fmt.Errorf("Condition %q can not be expressed as an interval", c.String())
+		}
+	}
+
+	if i.empty() {
+		return interval{}, errEmptyRange
+	}
+	return i, nil
+}
+
+// empty returns true if no version is within the interval.
+func (i interval) empty() bool {
+	if i.lower == nil || i.upper == nil {
+		return false
+	}
+	comp := i.lower.Version.Compare(i.upper.Version)
+	return comp > 0 || (comp == 0 && (i.lower.Operator == ">" || i.upper.Operator == "<"))
+}
+
+// contains checks if v is within the interval.
+func (i interval) contains(v Version) bool {
+	return (i.lower == nil || i.lower.rangeFunc()(v)) && (i.upper == nil || i.upper.rangeFunc()(v))
+}
+
+// toIntervals reduces a RangeSet to sorted, non overlapping intervals
+// and the versions excluded from them by "!=".
+// An error is returned if an exclusion can not be applied to all intervals.
+func toIntervals(rs RangeSet) ([]interval, []Version, error) {
+	var intervals []interval
+	var excluded []Version
+	for _, set := range rs {
+		var bounds, ne []Condition
+		for _, c := range set {
+			if c.Operator == "!=" {
+				ne = append(ne, c)
+			} else {
+				bounds = append(bounds, c)
+			}
+		}
+		i, err := toInterval(bounds)
+		if err == errEmptyRange {
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		for _, c := range ne {
+			if !i.contains(c.Version) {
+				continue
+			}
+			// Excluding a bound turns it exclusive
+			if i.lower != nil && i.lower.Version.EQ(c.Version) {
+				i.lower = &Condition{">", c.Version}
+			} else if i.upper != nil && i.upper.Version.EQ(c.Version) {
+				i.upper = &Condition{"<", c.Version}
+			} else {
+				excluded = append(excluded, c.Version)
+			}
+		}
+		if i.empty() {
+			continue
+		}
+		intervals = append(intervals, i)
+	}
+
+	sort.SliceStable(intervals, func(a, b int) bool {
+		return lowerLess(intervals[a].lower, intervals[b].lower)
+	})
+
+	var merged []interval
+	for _, i := range intervals {
+		if len(merged) == 0 {
+			merged = append(merged, i)
+			continue
+		}
+		last := &merged[len(merged)-1]
+		if last.upper == nil {
+			continue
+		}
+		if i.lower != nil {
+			comp := i.lower.Version.Compare(last.upper.Version)
+			if comp > 0 {
+				merged = append(merged, i)
+				continue
+			}
+			if comp == 0 && i.lower.Operator == ">" && last.upper.Operator == "<" {
+				// (,1.0.0) and (1.0.0,) only miss 1.0.0
+				excluded = append(excluded, i.lower.Version)
+			}
+		}
+		last.upper = looserUpper(last.upper, i.upper)
+	}
+
+	// Exclusions apply to all intervals, so no other set may match them
+	r := rs.Range()
+	sort.Sort(Versions(excluded))
+	var out []Version
+	for _, v := range excluded {
+		if r(v) {
+			return nil, nil, fmt.Errorf("Condition %q can not be expressed for all intervals", "!="+v.String())
+		}
+		if len(out) > 0 && out[len(out)-1].EQ(v) {
+			continue
+		}
+		out = append(out, v)
+	}
+	return merged, out, nil
+}
+
+// looserUpper returns the less restrictive of two upper bounds.
+func looserUpper(a, b *Condition) *Condition {
+	if a == nil || b == nil {
+		return nil
+	}
+	if b.Version.GT(a.Version) || (b.Version.EQ(a.Version) && b.Operator == "<=") {
+		return b
+	}
+	return a
+}
+
+// lowerLess orders lower bounds, a missing bound first and
+// inclusive before exclusive bounds of the same version.
+func lowerLess(a, b *Condition) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	if comp := a.Version.Compare(b.Version); comp != 0 {
+		return comp < 0
+	}
+	return a.Operator == ">=" && b.Operator == ">"
+}
+
+// tighterLower returns the more restrictive of two lower bounds.
+func tighterLower(b *Condition, c Condition) *Condition {
+	if b == nil || c.Version.GT(b.Version) || (c.Version.EQ(b.Version) && c.Operator == ">") {
+		return &c
+	}
+	return b
+}
+
+// tighterUpper returns the more restrictive of two upper bounds.
+func tighterUpper(b *Condition, c Condition) *Condition {
+	if b == nil || c.Version.LT(b.Version) || (c.Version.EQ(b.Version) && c.Operator == "<") {
+		return &c
+	}
+	return b
+}
+
+// splitIntervals splits sorted intervals at the sorted excluded versions,
+// so the exclusions are expressed by exclusive bounds instead of "!=".
+func splitIntervals(intervals []interval, excluded []Version) []interval {
+	var out []interval
+	for _, i := range intervals {
+		for _, v := range excluded {
+			if !i.contains(v) {
+				continue
+			}
+			if i.lower != nil && i.lower.Version.EQ(v) {
+				i.lower = &Condition{">", v}
+				continue
+			}
+			if i.upper != nil && i.upper.Version.EQ(v) {
+				i.upper = &Condition{"<", v}
+				continue
+			}
+			out = append(out, interval{i.lower, &Condition{"<", v}})
+			i.lower = &Condition{">", v}
+		}
+		if !i.empty() {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// intervalConditions reduces a RangeSet to the conditions of a single
+// interval followed by its "!=" exclusions, for dialects without logical OR.
+// An exact interval becomes "=" and an unbounded interval without
+// exclusions ">=0.0.0".
+func intervalConditions(rs RangeSet) ([]Condition, error) {
+	intervals, excluded, err := toIntervals(rs)
+	if err != nil {
+		return nil, err
+	}
+	if len(intervals) == 0 {
+		return nil, errEmptyRange
+	}
+	if len(intervals) > 1 {
+		return nil, errors.New("Logical OR can not be expressed")
+	}
+
+	var conds []Condition
+	i := intervals[0]
+	switch {
+	case i.exact():
+		conds = append(conds, Condition{"=", i.lower.Version})
+	case i.lower == nil && i.upper == nil:
+		if len(excluded) == 0 {
+			conds = append(conds, Condition{">=", Version{}})
+		}
+	default:
+		if i.lower != nil {
+			conds = append(conds, *i.lower)
+		}
+		if i.upper != nil {
+			conds = append(conds, *i.upper)
+		}
+	}
+	for _, v := range excluded {
+		conds = append(conds, Condition{"!=", v})
+	}
+	return conds, nil
+}
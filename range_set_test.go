@@ -0,0 +1,148 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestRangeSetString(t *testing.T) {
+	tests := []struct {
+		i string
+		o string
+	}{
+		{">1.2.3", ">1.2.3"},
+		{"1.2.3", "=1.2.3"},
+		{"==1.2.3", "=1.2.3"},
+		{"!1.2.3", "!=1.2.3"},
+		{">=1.0.0 <2.0.0 || >=3.0.0", ">=1.0.0 <2.0.0 || >=3.0.0"},
+		{"^1.2.3 || 2.x", ">=1.2.3 <2.0.0 || >=2.0.0 <3.0.0"},
+	}
+
+	for _, tc := range tests {
+		rs, err := ParseRangeSet(tc.i)
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		if o := rs.String(); o != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+		if _, err := ParseRange(rs.String()); err != nil {
+			t.Errorf("Invalid for case %q: String %q not parseable: %s", tc.i, rs.String(), err)
+		}
+	}
+}
+
+func TestRangeSetRange(t *testing.T) {
+	rs := RangeSet{
+		{{">=", MustParse("1.0.0")}, {"<", MustParse("2.0.0")}, {"!=", MustParse("1.5.0")}},
+		{{"=", MustParse("3.0.0")}},
+	}
+	r := rs.Range()
+	tests := []struct {
+		v string
+		b bool
+	}{
+		{"0.9.0", false},
+		{"1.0.0", true},
+		{"1.5.0", false},
+		{"1.9.9", true},
+		{"2.0.0", false},
+		{"3.0.0", true},
+		{"3.0.1", false},
+	}
+	for _, tc := range tests {
+		if res := r(MustParse(tc.v)); res != tc.b {
+			t.Errorf("Invalid for case %q matching %q: Expected %t, got: %t", rs, tc.v, tc.b, res)
+		}
+	}
+
+	for _, v := range []string{"0.0.0", "1.0.0", "2.0.0-rc.1"} {
+		if (RangeSet{}).Range()(MustParse(v)) {
+			t.Errorf("Empty RangeSet should not match %q", v)
+		}
+		if (RangeSet(nil)).Range()(MustParse(v)) {
+			t.Errorf("Nil RangeSet should not match %q", v)
+		}
+		if !(RangeSet{{}}).Range()(MustParse(v)) {
+			t.Errorf("Empty set of conditions should match %q", v)
+		}
+	}
+}
+
+func TestRangeSetValidate(t *testing.T) {
+	tests := []struct {
+		rs    RangeSet
+		valid bool
+	}{
+		{RangeSet{{{">=", MustParse("1.0.0")}, {"<", MustParse("2.0.0")}}}, true},
+		{RangeSet{{{"", MustParse("1.0.0")}}, {{"==", MustParse("2.0.0")}}, {{"!", MustParse("3.0.0")}}}, true},
+		{RangeSet{}, true},
+		{RangeSet{{{"~", MustParse("1.0.0")}}}, false},
+		{RangeSet{{{">=", MustParse("1.0.0")}}, {{"=>", MustParse("2.0.0")}}}, false},
+	}
+	for _, tc := range tests {
+		if err := tc.rs.Validate(); (err == nil) != tc.valid {
+			t.Errorf("Invalid for case %q: Expected valid %t, got error %v", tc.rs, tc.valid, err)
+		}
+	}
+
+	// Unknown operators match nothing instead of panicking
+	r := RangeSet{{{Operator: "~", Version: MustParse("1.0.0")}}}.Range()
+	if r(MustParse("1.0.0")) {
+		t.Errorf("Unknown operator should not match")
+	}
+	r = RangeSet{{{Operator: "~", Version: MustParse("1.0.0")}}, {{Operator: ">=", Version: MustParse("2.0.0")}}}.Range()
+	if r(MustParse("1.0.0")) || !r(MustParse("2.0.0")) {
+		t.Errorf("Unknown operator should only exclude its own set")
+	}
+}
+
+func TestToInterval(t *testing.T) {
+	tests := []struct {
+		i     string
+		lower string
+		upper string
+	}{
+		{">=1.0.0", ">=1.0.0", ""},
+		{"<2.0.0", "", "<2.0.0"},
+		{">=1.0.0 >=1.2.0 <3.0.0 <2.0.0", ">=1.2.0", "<2.0.0"},
+		{">=1.2.0 >1.2.0 <=2.0.0 <2.0.0", ">1.2.0", "<2.0.0"},
+		{"=1.2.3", ">=1.2.3", "<=1.2.3"},
+		{">=1.0.0 =1.2.3", ">=1.2.3", "<=1.2.3"},
+		// Errors
+		{">=1.0.0 !=1.2.3", "", ""},
+		{">2.0.0 <1.0.0", "", ""},
+		{"=1.0.0 =2.0.0", "", ""},
+	}
+
+	for _, tc := range tests {
+		rs, err := ParseRangeSet(tc.i)
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		i, err := toInterval(rs[0])
+		if err != nil {
+			if tc.lower != "" || tc.upper != "" {
+				t.Errorf("Invalid for case %q: Expected interval, got error %q", tc.i, err)
+			}
+			continue
+		}
+		if tc.lower == "" && tc.upper == "" {
+			t.Errorf("Invalid for case %q: Expected error, got none", tc.i)
+		}
+		if lower := conditionString(i.lower); lower != tc.lower {
+			t.Errorf("Invalid lower bound for case %q: Expected %q, got: %q", tc.i, tc.lower, lower)
+		}
+		if upper := conditionString(i.upper); upper != tc.upper {
+			t.Errorf("Invalid upper bound for case %q: Expected %q, got: %q", tc.i, tc.upper, upper)
+		}
+	}
+}
+
+func conditionString(c *Condition) string {
+	if c == nil {
+		return ""
+	}
+	return c.String()
+}
@@ -559,6 +559,48 @@ func TestParseRange(t *testing.T) {
 	}
 }
 
+func TestParseRangeStrict(t *testing.T) {
+	tests := []struct {
+		i     string
+		sugar string
+	}{
+		{">=1.2.3 <2.0.0", ""},
+		{"1.2.3", ""},
+		{"==1.2.3 || !=1.2.4", ""},
+		{"> 1.2.3 <= 1.2.5", ""},
+		{">=1.2.3-beta.1 !1.2.3", ""},
+		{"^1.2.3", "Caret range"},
+		{"^ 1.2.3", "Caret range"},
+		{"~1.2.3", "Tilde range"},
+		{"~>1.2", "Tilde range"},
+		{">=1.0.0 || ~2.1", "Tilde range"},
+		{"1.x", "X-range"},
+		{">=1.2", "X-range"},
+		{"1.2.*", "X-range"},
+		{"x", "Wildcard"},
+		{"*", "Wildcard"},
+		{"1.2.3 - 2.3.4", "Hyphen range"},
+	}
+
+	for _, tc := range tests {
+		_, err := ParseRangeWithOptions(tc.i, RangeOptions{Strict: true})
+		if tc.sugar == "" {
+			if err != nil {
+				t.Errorf("Invalid for case %q: Expected no error, got %q", tc.i, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("Invalid for case %q: Expected error, got none", tc.i)
+		} else if !strings.HasPrefix(err.Error(), tc.sugar) {
+			t.Errorf("Invalid for case %q: Expected %s error, got %q", tc.i, tc.sugar, err)
+		}
+		if _, err := ParseRangeWithOptions(tc.i, RangeOptions{}); err != nil {
+			t.Errorf("Invalid for case %q: Expected no error without strict mode, got %q", tc.i, err)
+		}
+	}
+}
+
 func TestMustParseRange(t *testing.T) {
 	testCase := ">1.2.2 <1.2.4 || >=2.0.0 <3.0.0"
 	r := MustParseRange(testCase)
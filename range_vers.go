@@ -0,0 +1,187 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// versOperators are the comparators of a vers constraint, longest first.
+var versOperators = []string{"!=", ">=", "<=", "=", ">", "<"}
+
+// ParseVers parses a package URL "vers" range specifier like
+// "vers:npm/>=1.0.0|<2.0.0|!=1.5.0" and returns the versioning scheme
+// and the RangeSet of the specifier.
+// If the specifier could not be parsed an error is returned.
+//
+// Constraints are separated by "|". Ignoring "=" and "!=", the constraints
+// sorted by version must alternate between lower (">", ">=") and
+// upper ("<", "<=") bounds, each pair forms an interval:
+//   - "vers:npm/>=1.0.0|<2.0.0|>=3.0.0" is ">=1.0.0 <2.0.0 || >=3.0.0"
+//   - "vers:npm/<1.0.0|=1.5.0" is "<1.0.0 || =1.5.0"
+//   - "vers:npm/!=1.5.0" is every version except 1.5.0
+//   - "vers:npm/*" is any version
+//
+// Versions are parsed as semver, missing minor and patch numbers are
+// filled up with 0.
+// See https://github.com/package-url/purl-spec/blob/master/VERSION-RANGE-SPEC.rst
+func ParseVers(s string) (string, RangeSet, error) {
+	scheme, parts, err := parseVers(s)
+	if err != nil {
+		return "", nil, fmt.Errorf("Could not parse vers %q: %s", s, err)
+	}
+	rs, err := buildRangeSet(parts)
+	if err != nil {
+		return "", nil, err
+	}
+	return scheme, rs, nil
+}
+
+// parseVers expands a vers specifier into comparators.
+func parseVers(s string) (string, [][]string, error) {
+	s = strings.Join(strings.Fields(s), "")
+	if !strings.HasPrefix(s, "vers:") {
+		return "", nil, errors.New("Missing vers: prefix")
+	}
+	s = strings.TrimPrefix(s, "vers:")
+	slash := strings.IndexRune(s, '/')
+	if slash < 1 {
+		return "", nil, errors.New("Missing versioning scheme")
+	}
+	scheme, s := strings.ToLower(s[:slash]), strings.Trim(s[slash+1:], "|")
+	if len(s) == 0 {
+		return "", nil, errors.New("Missing constraints")
+	}
+	if s == "*" {
+		return scheme, [][]string{{">=0.0.0"}}, nil
+	}
+
+	var bounds, equal, ne []Condition
+	seen := map[string]bool{}
+	for _, c := range strings.Split(s, "|") {
+		op := ""
+		for _, o := range versOperators {
+			if strings.HasPrefix(c, o) {
+				op = o
+				break
+			}
+		}
+		vStr, err := url.PathUnescape(c[len(op):])
+		if err != nil {
+			return "", nil, err
+		}
+		if vStr == "*" {
+			return "", nil, errors.New("Wildcard must be the only constraint")
+		}
+		v, err := parseShortVersion(vStr)
+		if err != nil {
+			return "", nil, err
+		}
+		if seen[v.String()] {
+			return "", nil, fmt.Errorf("Duplicate version %q", v.String())
+		}
+		seen[v.String()] = true
+
+		cond := Condition{normalizeOperator(op), v}
+		switch cond.Operator {
+		case "=":
+			equal = append(equal, cond)
+		case "!=":
+			ne = append(ne, cond)
+		default:
+			bounds = append(bounds, cond)
+		}
+	}
+
+	sort.SliceStable(bounds, func(i, j int) bool {
+		return bounds[i].Version.LT(bounds[j].Version)
+	})
+
+	var sets [][]Condition
+	i := 0
+	if len(bounds) > 0 && strings.HasPrefix(bounds[0].Operator, "<") {
+		sets = append(sets, bounds[:1])
+		i = 1
+	}
+	for ; i < len(bounds); i += 2 {
+		if !strings.HasPrefix(bounds[i].Operator, ">") {
+			return "", nil, fmt.Errorf("Expected lower bound instead of %q", bounds[i].String())
+		}
+		if i+1 == len(bounds) {
+			sets = append(sets, bounds[i:i+1])
+			break
+		}
+		if !strings.HasPrefix(bounds[i+1].Operator, "<") {
+			return "", nil, fmt.Errorf("Expected upper bound instead of %q", bounds[i+1].String())
+		}
+		sets = append(sets, bounds[i:i+2])
+	}
+	for _, c := range equal {
+		sets = append(sets, []Condition{c})
+	}
+	if len(sets) == 0 {
+		sets = append(sets, nil)
+	}
+
+	// Exclusions apply to every interval
+	var parts [][]string
+	for _, set := range sets {
+		var part []string
+		for _, c := range set {
+			part = append(part, c.String())
+		}
+		for _, c := range ne {
+			part = append(part, c.String())
+		}
+		parts = append(parts, part)
+	}
+	return scheme, parts, nil
+}
+
+// FormatVers formats a RangeSet as package URL "vers" range specifier
+// using the given versioning scheme, like "vers:npm/>=1.0.0|<2.0.0".
+// Overlapping sets are merged. An error is returned if the RangeSet can
+// not be expressed, e.g. if a "!=" only applies to some of the sets.
+func FormatVers(scheme string, rs RangeSet) (string, error) {
+	if len(scheme) == 0 {
+		return "", errors.New("Could not format vers: Missing versioning scheme")
+	}
+	intervals, excluded, err := toIntervals(rs)
+	if err != nil {
+		return "", fmt.Errorf("Could not format range %q as vers: %s", rs.String(), err)
+	}
+	if len(intervals) == 0 {
+		return "", fmt.Errorf("Could not format range %q as vers: %s", rs.String(), errEmptyRange)
+	}
+
+	var conds []Condition
+	for _, i := range intervals {
+		if i.exact() {
+			conds = append(conds, Condition{"=", i.lower.Version})
+			continue
+		}
+		if i.lower != nil {
+			conds = append(conds, *i.lower)
+		}
+		if i.upper != nil {
+			conds = append(conds, *i.upper)
+		}
+	}
+	for _, v := range excluded {
+		conds = append(conds, Condition{"!=", v})
+	}
+	if len(conds) == 0 {
+		return "vers:" + strings.ToLower(scheme) + "/*", nil
+	}
+
+	sort.SliceStable(conds, func(i, j int) bool {
+		return conds[i].Version.LT(conds[j].Version)
+	})
+	out := make([]string, 0, len(conds))
+	for _, c := range conds {
+		out = append(out, c.Operator+url.PathEscape(c.Version.String()))
+	}
+	return "vers:" + strings.ToLower(scheme) + "/" + strings.Join(out, "|"), nil
+}
@@ -0,0 +1,155 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseVersExpansion(t *testing.T) {
+	tests := []struct {
+		i      string
+		scheme string
+		o      [][]string
+	}{
+		{"vers:npm/>=1.0.0|<2.0.0", "npm", [][]string{{">=1.0.0", "<2.0.0"}}},
+		{"vers:npm/<2.0.0|>=1.0.0", "npm", [][]string{{">=1.0.0", "<2.0.0"}}},
+		{"vers:npm/>=1.0.0|<2.0.0|!=1.5.0", "npm", [][]string{{">=1.0.0", "<2.0.0", "!=1.5.0"}}},
+		{"vers:npm/<1.0.0|>=2.0.0|<3.0.0|>4.0.0", "npm", [][]string{{"<1.0.0"}, {">=2.0.0", "<3.0.0"}, {">4.0.0"}}},
+		{"vers:npm/1.2.3", "npm", [][]string{{"=1.2.3"}}},
+		{"vers:NPM/=1.2.3|=1.2.5", "npm", [][]string{{"=1.2.3"}, {"=1.2.5"}}},
+		{"vers:npm/<1.0.0|=1.5.0", "npm", [][]string{{"<1.0.0"}, {"=1.5.0"}}},
+		{"vers:npm/!=1.5.0", "npm", [][]string{{"!=1.5.0"}}},
+		{"vers:npm/*", "npm", [][]string{{">=0.0.0"}}},
+		{"vers:golang/>=v1.2 | <= v1.4", "golang", [][]string{{">=1.2.0", "<=1.4.0"}}},
+		{"vers:npm/>=1.0.0-beta%2B1", "npm", [][]string{{">=1.0.0-beta+1"}}},
+		// Errors
+		{"npm/>=1.0.0", "", nil},
+		{"vers:>=1.0.0", "", nil},
+		{"vers:npm/", "", nil},
+		{"vers:npm/*|>=1.0.0", "", nil},
+		{"vers:npm/>=1.0.0|>=2.0.0", "", nil},
+		{"vers:npm/<1.0.0|<2.0.0", "", nil},
+		{"vers:npm/>=1.0.0|<1.0.0", "", nil},
+		{"vers:npm/>=foo", "", nil},
+	}
+
+	for _, tc := range tests {
+		scheme, o, err := parseVers(tc.i)
+		if err != nil {
+			if tc.o != nil {
+				t.Errorf("Invalid for case %q: Expected %q, got error %q", tc.i, tc.o, err)
+			}
+			continue
+		} else if tc.o == nil {
+			t.Errorf("Invalid for case %q: Expected error, got %q", tc.i, o)
+			continue
+		}
+		if scheme != tc.scheme {
+			t.Errorf("Invalid scheme for case %q: Expected %q, got: %q", tc.i, tc.scheme, scheme)
+		}
+		if !reflect.DeepEqual(tc.o, o) {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+	}
+}
+
+func TestParseVers(t *testing.T) {
+	type tv struct {
+		v string
+		b bool
+	}
+	tests := []struct {
+		i string
+		t []tv
+	}{
+		{"vers:npm/>=1.0.0|<2.0.0|!=1.5.0", []tv{
+			{"0.9.0", false},
+			{"1.0.0", true},
+			{"1.5.0", false},
+			{"1.9.9", true},
+			{"2.0.0", false},
+		}},
+		{"vers:npm/<1.0.0|>=2.0.0|<3.0.0|=5.0.0", []tv{
+			{"0.9.0", true},
+			{"1.0.0", false},
+			{"2.5.0", true},
+			{"3.0.0", false},
+			{"5.0.0", true},
+		}},
+	}
+
+	for _, tc := range tests {
+		_, rs, err := ParseVers(tc.i)
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		r := rs.Range()
+		for _, tvc := range tc.t {
+			v := MustParse(tvc.v)
+			if res := r(v); res != tvc.b {
+				t.Errorf("Invalid for case %q matching %q: Expected %t, got: %t", tc.i, tvc.v, tvc.b, res)
+			}
+		}
+	}
+}
+
+func TestFormatVers(t *testing.T) {
+	tests := []struct {
+		i string
+		o string
+	}{
+		{">=1.0.0 <2.0.0", "vers:npm/>=1.0.0|<2.0.0"},
+		{">=1.0.0 <2.0.0 !=1.5.0", "vers:npm/>=1.0.0|!=1.5.0|<2.0.0"},
+		{">=1.0.0 <2.0.0 !=1.0.0 !=3.0.0", "vers:npm/>1.0.0|<2.0.0"},
+		{"<1.0.0 || >=2.0.0 <3.0.0 || 5.0.0", "vers:npm/<1.0.0|>=2.0.0|<3.0.0|=5.0.0"},
+		{">=2.0.0 <3.0.0 || <1.0.0", "vers:npm/<1.0.0|>=2.0.0|<3.0.0"},
+		{">=1.0.0 <2.0.0 || >=1.5.0 <3.0.0", "vers:npm/>=1.0.0|<3.0.0"},
+		{">=1.0.0 <2.0.0 || 2.0.0", "vers:npm/>=1.0.0|<=2.0.0"},
+		{"<1.0.0 || >1.0.0", "vers:npm/!=1.0.0"},
+		{"!=1.0.0", "vers:npm/!=1.0.0"},
+		{"*", "vers:npm/>=0.0.0"},
+		{"^1.2.3 || ^2.0.0", "vers:npm/>=1.2.3|<3.0.0"},
+		// Errors
+		{">=1.0.0 <2.0.0 !=1.5.0 || >=1.2.0 <1.8.0", ""},
+		{">2.0.0 <1.0.0", ""},
+	}
+
+	for _, tc := range tests {
+		rs, err := ParseRangeSet(tc.i)
+		if err != nil {
+			t.Errorf("Error parsing range %q: %s", tc.i, err)
+			continue
+		}
+		o, err := FormatVers("npm", rs)
+		if err != nil {
+			if tc.o != "" {
+				t.Errorf("Invalid for case %q: Expected %q, got error %q", tc.i, tc.o, err)
+			}
+			continue
+		} else if tc.o == "" {
+			t.Errorf("Invalid for case %q: Expected error, got %q", tc.i, o)
+			continue
+		}
+		if o != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+
+		// Round trip
+		_, prs, err := ParseVers(o)
+		if err != nil {
+			t.Errorf("Invalid for case %q: Formatted vers %q not parseable: %s", tc.i, o, err)
+			continue
+		}
+		r, pr := rs.Range(), prs.Range()
+		for _, v := range []string{"0.0.1", "0.9.9", "1.0.0", "1.2.3", "1.5.0", "1.9.9", "2.0.0", "2.5.0", "3.0.0", "5.0.0", "9.0.0"} {
+			if r(MustParse(v)) != pr(MustParse(v)) {
+				t.Errorf("Invalid for case %q: Formatted vers %q differs for %q", tc.i, o, v)
+			}
+		}
+	}
+
+	if _, err := FormatVers("", RangeSet{{{">=", MustParse("1.0.0")}}}); err == nil {
+		t.Errorf("Expected error for missing scheme, got none")
+	}
+}
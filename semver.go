@@ -130,18 +130,83 @@ func (v Version) Compare(o Version) int {
 		return -1
 	}
 
+	return comparePre(v.Pre, o.Pre)
+}
+
+// CompareStrict compares Versions v to o like Compare, but breaks ties by
+// the build meta data to get a total order:
+// -1 == v is less than o
+// 0 == v is identical to o
+// 1 == v is greater than o
+//
+// A version without build meta data is less than one with build meta data.
+// Build identifiers are compared one by one, numeric identifiers by value
+// and lower than alphanumeric identifiers, which are compared lexically.
+// Numeric identifiers with equal value but different leading zeroes are
+// compared lexically. If all identifiers are equal, fewer identifiers are less.
+func (v Version) CompareStrict(o Version) int {
+	if comp := v.Compare(o); comp != 0 {
+		return comp
+	}
+	for i := 0; i < len(v.Build) && i < len(o.Build); i++ {
+		if comp := compareBuild(v.Build[i], o.Build[i]); comp != 0 {
+			return comp
+		}
+	}
+	if len(v.Build) != len(o.Build) {
+		if len(v.Build) > len(o.Build) {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+// EqualExact checks if v is identical to o, including build meta data.
+func (v Version) EqualExact(o Version) bool {
+	return (v.CompareStrict(o) == 0)
+}
+
+// compareBuild compares two build identifiers.
+func compareBuild(a, b string) int {
+	aNum, bNum := containsOnly(a, numbers), containsOnly(b, numbers)
+	if aNum && bNum {
+		if comp := compareDigits(trimLeadingZeroes(a), trimLeadingZeroes(b)); comp != 0 {
+			return comp
+		}
+	} else if aNum != bNum {
+		if aNum {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// trimLeadingZeroes removes leading zeroes, keeping a single 0.
+func trimLeadingZeroes(s string) string {
+	t := strings.TrimLeft(s, "0")
+	if len(t) == 0 && len(s) > 0 {
+		return "0"
+	}
+	return t
+}
+
+// comparePre compares the prerelease versions of two versions
+// with equal major, minor and patch numbers.
+func comparePre(v, o []PRVersion) int {
 	// Quick comparison if a version has no prerelease versions
-	if len(v.Pre) == 0 && len(o.Pre) == 0 {
+	if len(v) == 0 && len(o) == 0 {
 		return 0
-	} else if len(v.Pre) == 0 && len(o.Pre) > 0 {
+	} else if len(v) == 0 && len(o) > 0 {
 		return 1
-	} else if len(v.Pre) > 0 && len(o.Pre) == 0 {
+	} else if len(v) > 0 && len(o) == 0 {
 		return -1
 	}
 
 	i := 0
-	for ; i < len(v.Pre) && i < len(o.Pre); i++ {
-		if comp := v.Pre[i].Compare(o.Pre[i]); comp == 0 {
+	for ; i < len(v) && i < len(o); i++ {
+		if comp := v[i].Compare(o[i]); comp == 0 {
 			continue
 		} else if comp == 1 {
 			return 1
@@ -151,9 +216,9 @@ func (v Version) Compare(o Version) int {
 	}
 
 	// If all pr versions are the equal but one has further prversion, this one greater
-	if i == len(v.Pre) && i == len(o.Pre) {
+	if i == len(v) && i == len(o) {
 		return 0
-	} else if i == len(v.Pre) && i < len(o.Pre) {
+	} else if i == len(v) && i < len(o) {
 		return -1
 	} else {
 		return 1
@@ -162,6 +227,7 @@ func (v Version) Compare(o Version) int {
 }
 
 // IncrementPatch increments the patch version
+// Prerelease and build meta data are kept, use BumpPatch to drop them
 func (v *Version) IncrementPatch() error {
 	if v.Major == 0 {
 		return fmt.Errorf("Patch version can not be incremented for %q", v.String())
@@ -171,6 +237,7 @@ func (v *Version) IncrementPatch() error {
 }
 
 // IncrementMinor increments the minor version
+// Prerelease and build meta data are kept, use BumpMinor to drop them
 func (v *Version) IncrementMinor() error {
 	if v.Major == 0 {
 		return fmt.Errorf("Minor version can not be incremented for %q", v.String())
@@ -181,6 +248,7 @@ func (v *Version) IncrementMinor() error {
 }
 
 // IncrementMajor increments the major version
+// Prerelease and build meta data are kept, use BumpMajor to drop them
 func (v *Version) IncrementMajor() error {
 	if v.Major == 0 {
 		return fmt.Errorf("Major version can not be incremented for %q", v.String())
@@ -260,44 +328,140 @@ func ParseTolerant(s string) (Version, error) {
 	return Parse(s)
 }
 
-// Parse parses version string and returns a validated Version or error
+// ParseOptions control how lenient ParseWithOptions handles its input.
+// The zero value only accepts strict semver versions like "1.2.3-rc.1+build.5".
+type ParseOptions struct {
+	// AllowPrefix accepts a "v" or "V" prefix like "v1.2.3".
+	AllowPrefix bool
+	// AllowShort fills up missing minor and patch numbers with 0,
+	// so "1" and "1.2" become 1.0.0 and 1.2.0. Short versions can not
+	// contain prerelease or build meta data.
+	AllowShort bool
+	// AllowLeadingZeros removes leading zeros of the major, minor and
+	// patch numbers, so "01.02.03" becomes 1.2.3.
+	AllowLeadingZeros bool
+	// AllowMissingPrereleaseHyphen accepts a prerelease directly following
+	// the patch number, so "1.2.3rc.1" becomes 1.2.3-rc.1.
+	AllowMissingPrereleaseHyphen bool
+	// AllowWhitespace trims leading and trailing whitespace.
+	AllowWhitespace bool
+	// AllowBigPrereleaseNumbers accepts numeric prerelease identifiers
+	// exceeding uint64, like the timestamp in 1.0.0-20231012153000123456789.
+	// They are compared by their numeric value. Major, minor and patch
+	// numbers exceeding uint64 still fail with ErrOverflow.
+	AllowBigPrereleaseNumbers bool
+	// MaxLength is the maximum length of the version string, 0 for no limit.
+	MaxLength int
+}
+
+// ParseWithOptions parses a version string like Parse, but only as lenient
+// as allowed by the options, and returns a validated Version or error.
+// Unlike Parse, a "v" prefix is rejected unless AllowPrefix is set.
+// The error is a *ParseError, its offsets refer to the normalized version string.
+func ParseWithOptions(s string, opts ParseOptions) (Version, error) {
+	if opts.MaxLength > 0 && len(s) > opts.MaxLength {
+		return Version{}, newParseError(s, "", opts.MaxLength, ErrTooLong, fmt.Sprintf("Version string longer than %d characters", opts.MaxLength))
+	}
+	if opts.AllowWhitespace {
+		s = strings.TrimSpace(s)
+	}
+	if len(s) == 0 {
+		return Version{}, newParseError(s, "", 0, ErrEmpty, "Version string empty")
+	}
+	if s[0] == 'v' || s[0] == 'V' {
+		if !opts.AllowPrefix {
+			return Version{}, newParseError(s, "major", 0, ErrInvalidChar, fmt.Sprintf("Prefix %q not allowed in %q", s[:1], s))
+		}
+		s = s[1:]
+	}
+
+	core, suffix := s, ""
+	if i := strings.IndexAny(s, "-+"); i != -1 {
+		core, suffix = s[:i], s[i:]
+	}
+	if opts.AllowMissingPrereleaseHyphen {
+		if i := strings.IndexFunc(core, func(r rune) bool { return !strings.ContainsRune(numbers+".", r) }); i != -1 && i > 0 && core[i-1] != '.' {
+			core, suffix = core[:i], "-"+core[i:]+suffix
+		}
+	}
+
+	parts := strings.Split(core, ".")
+	if !containsOnly(parts[0], numbers) {
+		// Parse would strip another "v" prefix
+		return Version{}, newParseError(s, "major", 0, ErrInvalidChar, fmt.Sprintf("Invalid character(s) found in major number %q", parts[0]))
+	}
+	if opts.AllowLeadingZeros {
+		for i, p := range parts {
+			if t := strings.TrimLeft(p, "0"); len(t) > 0 {
+				parts[i] = t
+			} else if len(p) > 0 {
+				parts[i] = "0"
+			}
+		}
+	}
+	if opts.AllowShort && len(parts) < 3 {
+		if len(suffix) > 0 {
+			return Version{}, newParseError(s, "", 0, ErrMissingComponent, "Short version cannot contain PreRelease/Build meta data")
+		}
+		for len(parts) < 3 {
+			parts = append(parts, "0")
+		}
+	}
+	return parse(strings.Join(parts, ".")+suffix, opts.AllowBigPrereleaseNumbers)
+}
+
+// Parse parses version string and returns a validated Version or error.
+// The error is a *ParseError.
 func Parse(s string) (Version, error) {
+	return parse(s, false)
+}
+
+// parse parses a version string, allowing numeric prerelease identifiers
+// exceeding uint64 if bigNumbers is set.
+func parse(s string, bigNumbers bool) (Version, error) {
 	if len(s) == 0 {
-		return Version{}, errors.New("Version string empty")
+		return Version{}, newParseError(s, "", 0, ErrEmpty, "Version string empty")
 	}
+	input := s
 
 	// strip off any leading 'v' if present
-	s = strings.TrimPrefix(s, "v")
+	offset := 0
+	if strings.HasPrefix(s, "v") {
+		s = s[1:]
+		offset = 1
+	}
 
 	// Split into major.minor.(patch+pr+meta)
 	parts := strings.SplitN(s, ".", 3)
 	if len(parts) != 3 {
-		return Version{}, errors.New("No Major.Minor.Patch elements found")
+		return Version{}, newParseError(input, "", 0, ErrMissingComponent, "No Major.Minor.Patch elements found")
 	}
 
 	// Major
 	if !containsOnly(parts[0], numbers) {
-		return Version{}, fmt.Errorf("Invalid character(s) found in major number %q", parts[0])
+		return Version{}, newParseError(input, "major", offset, ErrInvalidChar, fmt.Sprintf("Invalid character(s) found in major number %q", parts[0]))
 	}
 	if hasLeadingZeroes(parts[0]) {
-		return Version{}, fmt.Errorf("Major number must not contain leading zeroes %q", parts[0])
+		return Version{}, newParseError(input, "major", offset, ErrLeadingZero, fmt.Sprintf("Major number must not contain leading zeroes %q", parts[0]))
 	}
-	major, err := strconv.ParseUint(parts[0], 10, 64)
+	major, err := parseNumber(input, "major", offset, parts[0])
 	if err != nil {
 		return Version{}, err
 	}
+	offset += len(parts[0]) + 1
 
 	// Minor
 	if !containsOnly(parts[1], numbers) {
-		return Version{}, fmt.Errorf("Invalid character(s) found in minor number %q", parts[1])
+		return Version{}, newParseError(input, "minor", offset, ErrInvalidChar, fmt.Sprintf("Invalid character(s) found in minor number %q", parts[1]))
 	}
 	if hasLeadingZeroes(parts[1]) {
-		return Version{}, fmt.Errorf("Minor number must not contain leading zeroes %q", parts[1])
+		return Version{}, newParseError(input, "minor", offset, ErrLeadingZero, fmt.Sprintf("Minor number must not contain leading zeroes %q", parts[1]))
 	}
-	minor, err := strconv.ParseUint(parts[1], 10, 64)
+	minor, err := parseNumber(input, "minor", offset, parts[1])
 	if err != nil {
 		return Version{}, err
 	}
+	offset += len(parts[1]) + 1
 
 	v := Version{}
 	v.Major = major
@@ -305,24 +469,27 @@ func Parse(s string) (Version, error) {
 
 	var build, prerelease []string
 	patchStr := parts[2]
+	buildOffset, preOffset := 0, 0
 
 	if buildIndex := strings.IndexRune(patchStr, '+'); buildIndex != -1 {
 		build = strings.Split(patchStr[buildIndex+1:], ".")
 		patchStr = patchStr[:buildIndex]
+		buildOffset = offset + buildIndex + 1
 	}
 
 	if preIndex := strings.IndexRune(patchStr, '-'); preIndex != -1 {
 		prerelease = strings.Split(patchStr[preIndex+1:], ".")
 		patchStr = patchStr[:preIndex]
+		preOffset = offset + preIndex + 1
 	}
 
 	if !containsOnly(patchStr, numbers) {
-		return Version{}, fmt.Errorf("Invalid character(s) found in patch number %q", patchStr)
+		return Version{}, newParseError(input, "patch", offset, ErrInvalidChar, fmt.Sprintf("Invalid character(s) found in patch number %q", patchStr))
 	}
 	if hasLeadingZeroes(patchStr) {
-		return Version{}, fmt.Errorf("Patch number must not contain leading zeroes %q", patchStr)
+		return Version{}, newParseError(input, "patch", offset, ErrLeadingZero, fmt.Sprintf("Patch number must not contain leading zeroes %q", patchStr))
 	}
-	patch, err := strconv.ParseUint(patchStr, 10, 64)
+	patch, err := parseNumber(input, "patch", offset, patchStr)
 	if err != nil {
 		return Version{}, err
 	}
@@ -331,22 +498,24 @@ func Parse(s string) (Version, error) {
 
 	// Prerelease
 	for _, prstr := range prerelease {
-		parsedPR, err := NewPRVersion(prstr)
+		parsedPR, err := newPRVersion(prstr, bigNumbers)
 		if err != nil {
-			return Version{}, err
+			return Version{}, relocateParseError(err, input, preOffset)
 		}
 		v.Pre = append(v.Pre, parsedPR)
+		preOffset += len(prstr) + 1
 	}
 
 	// Build meta data
 	for _, str := range build {
 		if len(str) == 0 {
-			return Version{}, errors.New("Build meta data is empty")
+			return Version{}, newParseError(input, "build", buildOffset, ErrEmpty, "Build meta data is empty")
 		}
 		if !containsOnly(str, alphanum) {
-			return Version{}, fmt.Errorf("Invalid character(s) found in build meta data %q", str)
+			return Version{}, newParseError(input, "build", buildOffset, ErrInvalidChar, fmt.Sprintf("Invalid character(s) found in build meta data %q", str))
 		}
 		v.Build = append(v.Build, str)
+		buildOffset += len(str) + 1
 	}
 
 	return v, nil
@@ -361,27 +530,37 @@ func MustParse(s string) Version {
 	return v
 }
 
-// PRVersion represents a PreRelease Version
+// PRVersion represents a PreRelease Version.
+// Numeric identifiers exceeding uint64, only accepted by ParseWithOptions
+// with AllowBigPrereleaseNumbers, are kept as digits in VersionStr.
 type PRVersion struct {
 	VersionStr string
 	VersionNum uint64
 	IsNum      bool
 }
 
-// NewPRVersion creates a new valid prerelease version
+// NewPRVersion creates a new valid prerelease version.
+// The error is a *ParseError.
 func NewPRVersion(s string) (PRVersion, error) {
+	return newPRVersion(s, false)
+}
+
+// newPRVersion creates a new valid prerelease version, keeping numbers
+// exceeding uint64 as digit string if bigNumbers is set.
+func newPRVersion(s string, bigNumbers bool) (PRVersion, error) {
 	if len(s) == 0 {
-		return PRVersion{}, errors.New("Prerelease is empty")
+		return PRVersion{}, newParseError(s, "prerelease", 0, ErrEmpty, "Prerelease is empty")
 	}
 	v := PRVersion{}
 	if containsOnly(s, numbers) {
 		if hasLeadingZeroes(s) {
-			return PRVersion{}, fmt.Errorf("Numeric PreRelease version must not contain leading zeroes %q", s)
+			return PRVersion{}, newParseError(s, "prerelease", 0, ErrLeadingZero, fmt.Sprintf("Numeric PreRelease version must not contain leading zeroes %q", s))
 		}
-		num, err := strconv.ParseUint(s, 10, 64)
-
-		// Might never be hit, but just in case
+		num, err := parseNumber(s, "prerelease", 0, s)
 		if err != nil {
+			if bigNumbers && errors.Is(err, ErrOverflow) {
+				return PRVersion{VersionStr: s, IsNum: true}, nil
+			}
 			return PRVersion{}, err
 		}
 		v.VersionNum = num
@@ -390,7 +569,7 @@ func NewPRVersion(s string) (PRVersion, error) {
 		v.VersionStr = s
 		v.IsNum = false
 	} else {
-		return PRVersion{}, fmt.Errorf("Invalid character(s) found in prerelease %q", s)
+		return PRVersion{}, newParseError(s, "prerelease", 0, ErrInvalidChar, fmt.Sprintf("Invalid character(s) found in prerelease %q", s))
 	}
 	return v, nil
 }
@@ -410,6 +589,9 @@ func (v PRVersion) Compare(o PRVersion) int {
 	} else if !v.IsNum && o.IsNum {
 		return 1
 	} else if v.IsNum && o.IsNum {
+		if len(v.VersionStr) > 0 || len(o.VersionStr) > 0 {
+			return compareDigits(v.String(), o.String())
+		}
 		if v.VersionNum == o.VersionNum {
 			return 0
 		} else if v.VersionNum > o.VersionNum {
@@ -430,12 +612,23 @@ func (v PRVersion) Compare(o PRVersion) int {
 
 // PreRelease version to string
 func (v PRVersion) String() string {
-	if v.IsNum {
+	if v.IsNum && len(v.VersionStr) == 0 {
 		return strconv.FormatUint(v.VersionNum, 10)
 	}
 	return v.VersionStr
 }
 
+// compareDigits compares two numbers given as digits without leading zeroes.
+func compareDigits(a, b string) int {
+	if len(a) != len(b) {
+		if len(a) > len(b) {
+			return 1
+		}
+		return -1
+	}
+	return strings.Compare(a, b)
+}
+
 func containsOnly(s string, set string) bool {
 	return strings.IndexFunc(s, func(r rune) bool {
 		return !strings.ContainsRune(set, r)
@@ -446,13 +639,14 @@ func hasLeadingZeroes(s string) bool {
 	return len(s) > 1 && s[0] == '0'
 }
 
-// NewBuildVersion creates a new valid build version
+// NewBuildVersion creates a new valid build version.
+// The error is a *ParseError.
 func NewBuildVersion(s string) (string, error) {
 	if len(s) == 0 {
-		return "", errors.New("Buildversion is empty")
+		return "", newParseError(s, "build", 0, ErrEmpty, "Buildversion is empty")
 	}
 	if !containsOnly(s, alphanum) {
-		return "", fmt.Errorf("Invalid character(s) found in build meta data %q", s)
+		return "", newParseError(s, "build", 0, ErrInvalidChar, fmt.Sprintf("Invalid character(s) found in build meta data %q", s))
 	}
 	return s, nil
 }
@@ -1,6 +1,7 @@
 package semver
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -516,3 +517,150 @@ func BenchmarkCompareAverage(b *testing.B) {
 		compareTests[n%l].v1.Compare((compareTests[n%l].v2))
 	}
 }
+
+func TestParseWithOptions(t *testing.T) {
+	lenient := ParseOptions{
+		AllowPrefix:                  true,
+		AllowShort:                   true,
+		AllowLeadingZeros:            true,
+		AllowMissingPrereleaseHyphen: true,
+		AllowWhitespace:              true,
+	}
+	tests := []struct {
+		s    string
+		opts ParseOptions
+		o    string
+	}{
+		{"1.2.3-rc.1+build.5", ParseOptions{}, "1.2.3-rc.1+build.5"},
+		{"v1.2.3", ParseOptions{AllowPrefix: true}, "1.2.3"},
+		{"V1.2.3", ParseOptions{AllowPrefix: true}, "1.2.3"},
+		{"1.2", ParseOptions{AllowShort: true}, "1.2.0"},
+		{"1", ParseOptions{AllowShort: true}, "1.0.0"},
+		{"01.002.000", ParseOptions{AllowLeadingZeros: true}, "1.2.0"},
+		{"1.2.3rc.1", ParseOptions{AllowMissingPrereleaseHyphen: true}, "1.2.3-rc.1"},
+		{"1.2.3beta+build", ParseOptions{AllowMissingPrereleaseHyphen: true}, "1.2.3-beta+build"},
+		{" 1.2.3 ", ParseOptions{AllowWhitespace: true}, "1.2.3"},
+		{"1.2.3", ParseOptions{MaxLength: 5}, "1.2.3"},
+		{" v01.2rc.1 ", lenient, ""},
+		{" v01.2 ", lenient, "1.2.0"},
+		{"V1.02.3alpha.1", lenient, "1.2.3-alpha.1"},
+		// Errors
+		{"", ParseOptions{}, ""},
+		{"v1.2.3", ParseOptions{}, ""},
+		{"vv1.2.3", ParseOptions{AllowPrefix: true}, ""},
+		{"1.2", ParseOptions{}, ""},
+		{"1.2-rc.1", ParseOptions{AllowShort: true}, ""},
+		{"01.2.3", ParseOptions{}, ""},
+		{"1.2.3-01", ParseOptions{AllowLeadingZeros: true}, ""},
+		{"1.2.3rc.1", ParseOptions{}, ""},
+		{"1.rc.3", ParseOptions{AllowMissingPrereleaseHyphen: true}, ""},
+		{" 1.2.3", ParseOptions{}, ""},
+		{"1.2.3-rc.1", ParseOptions{MaxLength: 5}, ""},
+		{"1.2.3.4", lenient, ""},
+	}
+
+	for _, test := range tests {
+		v, err := ParseWithOptions(test.s, test.opts)
+		if err != nil {
+			if test.o != "" {
+				t.Errorf("Error parsing %q with %+v: %q", test.s, test.opts, err)
+			}
+			continue
+		}
+		if test.o == "" {
+			t.Errorf("Parsing %q with %+v, expected error but got %q", test.s, test.opts, v)
+		} else if v.String() != test.o {
+			t.Errorf("Parsing %q with %+v, expected %q but got %q", test.s, test.opts, test.o, v)
+		}
+	}
+}
+
+func TestParseBigPrereleaseNumbers(t *testing.T) {
+	opts := ParseOptions{AllowBigPrereleaseNumbers: true}
+	tests := []struct {
+		s string
+		o string
+	}{
+		{"1.0.0-20231012153000123456789", "1.0.0-20231012153000123456789"},
+		{"1.0.0-rc.18446744073709551616+build", "1.0.0-rc.18446744073709551616+build"},
+		{"1.0.0-18446744073709551615", "1.0.0-18446744073709551615"},
+		// Errors
+		{"1.0.0-020231012153000123456789", ""},
+		{"1.0.18446744073709551616", ""},
+	}
+	for _, test := range tests {
+		v, err := ParseWithOptions(test.s, opts)
+		if err != nil {
+			if test.o != "" {
+				t.Errorf("Error parsing %q: %q", test.s, err)
+			}
+			continue
+		}
+		if test.o == "" {
+			t.Errorf("Parsing %q, expected error but got %q", test.s, v)
+		} else if v.String() != test.o {
+			t.Errorf("Parsing %q, expected %q but got %q", test.s, test.o, v)
+		}
+	}
+
+	if v, err := ParseWithOptions("1.0.0-18446744073709551615", opts); err != nil || v.Pre[0].VersionStr != "" {
+		t.Errorf("Expected uint64 prerelease number, got %+v (%v)", v.Pre, err)
+	}
+	if _, err := Parse("1.0.0-20231012153000123456789"); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow without option, got %q", err)
+	}
+
+	ordered := []string{
+		"1.0.0-1",
+		"1.0.0-18446744073709551615",
+		"1.0.0-18446744073709551616",
+		"1.0.0-20231012153000123456789",
+		"1.0.0-99999999999999999999999",
+		"1.0.0-100000000000000000000000",
+		"1.0.0-alpha",
+	}
+	for i := 1; i < len(ordered); i++ {
+		a, _ := ParseWithOptions(ordered[i-1], opts)
+		b, _ := ParseWithOptions(ordered[i], opts)
+		if !a.LT(b) || !b.GT(a) {
+			t.Errorf("%q should be less than %q", a, b)
+		}
+		if !b.EQ(b) {
+			t.Errorf("%q should be equal to itself", b)
+		}
+	}
+}
+
+func TestCompareStrict(t *testing.T) {
+	tests := []struct {
+		a string
+		b string
+		o int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0+a", "1.0.0+a", 0},
+		{"1.0.0+a.1", "1.0.0+a.1", 0},
+		{"1.0.0+a", "1.0.0+b", -1},
+		{"1.0.0", "1.0.0+a", -1},
+		{"1.0.0+a", "1.0.0+a.1", -1},
+		{"1.0.0+2", "1.0.0+10", -1},
+		{"1.0.0+10", "1.0.0+a", -1},
+		{"1.0.0+1", "1.0.0+01", 1},
+		{"1.0.0+99999999999999999999", "1.0.0+100000000000000000000", -1},
+		{"1.0.0+build-1", "1.0.0+build-2", -1},
+		{"1.0.0-rc.1+z", "1.0.0+a", -1},
+		{"2.0.0+a", "1.0.0+b", 1},
+	}
+	for _, test := range tests {
+		a, b := MustParse(test.a), MustParse(test.b)
+		if comp := a.CompareStrict(b); comp != test.o {
+			t.Errorf("Comparing %q to %q, expected %d but got %d", a, b, test.o, comp)
+		}
+		if comp := b.CompareStrict(a); comp != -test.o {
+			t.Errorf("Comparing %q to %q, expected %d but got %d", b, a, -test.o, comp)
+		}
+		if eq := a.EqualExact(b); eq != (test.o == 0) {
+			t.Errorf("Exact equality of %q and %q, expected %t but got %t", a, b, test.o == 0, eq)
+		}
+	}
+}
@@ -26,3 +26,28 @@ func (s Versions) Less(i, j int) bool {
 func Sort(versions []Version) {
 	sort.Sort(Versions(versions))
 }
+
+// StrictVersions represents multiple versions sorted by CompareStrict,
+// so versions differing only in build meta data have a deterministic order.
+type StrictVersions []Version
+
+// Len returns length of version collection
+func (s StrictVersions) Len() int {
+	return len(s)
+}
+
+// Swap swaps two versions inside the collection by its indices
+func (s StrictVersions) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less checks if version at index i is less than version at index j,
+// including build meta data
+func (s StrictVersions) Less(i, j int) bool {
+	return s[i].CompareStrict(s[j]) < 0
+}
+
+// SortStrict sorts a slice of versions, breaking ties by build meta data
+func SortStrict(versions []Version) {
+	sort.Sort(StrictVersions(versions))
+}
@@ -18,6 +18,26 @@ func TestSort(t *testing.T) {
 	}
 }
 
+func TestSortStrict(t *testing.T) {
+	versions := []Version{
+		MustParse("1.0.0+b"),
+		MustParse("1.0.0+a.2"),
+		MustParse("1.0.0"),
+		MustParse("0.9.0+z"),
+		MustParse("1.0.0+a.10"),
+		MustParse("1.0.0+a"),
+		MustParse("1.0.0-rc.1+z"),
+	}
+	SortStrict(versions)
+
+	correct := []string{"0.9.0+z", "1.0.0-rc.1+z", "1.0.0", "1.0.0+a", "1.0.0+a.2", "1.0.0+a.10", "1.0.0+b"}
+	for i, c := range correct {
+		if versions[i].String() != c {
+			t.Fatalf("SortStrict returned wrong order: %s", versions)
+		}
+	}
+}
+
 func BenchmarkSort(b *testing.B) {
 	v100, _ := Parse("1.0.0")
 	v010, _ := Parse("0.1.0")
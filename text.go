@@ -0,0 +1,53 @@
+package semver
+
+import (
+	"encoding/xml"
+)
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *Version) UnmarshalText(data []byte) (err error) {
+	*v, err = Parse(string(data))
+
+	return
+}
+
+// MarshalXMLAttr implements the encoding/xml.MarshalerAttr interface.
+func (v Version) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: v.String()}, nil
+}
+
+// UnmarshalXMLAttr implements the encoding/xml.UnmarshalerAttr interface.
+func (v *Version) UnmarshalXMLAttr(attr xml.Attr) (err error) {
+	*v, err = Parse(attr.Value)
+
+	return
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v PRVersion) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *PRVersion) UnmarshalText(data []byte) (err error) {
+	*v, err = NewPRVersion(string(data))
+
+	return
+}
+
+// MarshalXMLAttr implements the encoding/xml.MarshalerAttr interface.
+func (v PRVersion) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: v.String()}, nil
+}
+
+// UnmarshalXMLAttr implements the encoding/xml.UnmarshalerAttr interface.
+func (v *PRVersion) UnmarshalXMLAttr(attr xml.Attr) (err error) {
+	*v, err = NewPRVersion(attr.Value)
+
+	return
+}
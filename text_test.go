@@ -0,0 +1,151 @@
+package semver
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+)
+
+func TestTextMarshal(t *testing.T) {
+	tests := []struct {
+		v      Version
+		result string
+	}{
+		{Version{1, 2, 3, nil, nil}, "1.2.3"},
+		{MustParse("3.1.4-alpha.1.5.9+build.2.6.5"), "3.1.4-alpha.1.5.9+build.2.6.5"},
+	}
+	for _, test := range tests {
+		b, err := test.v.MarshalText()
+		if err != nil {
+			t.Errorf("Unexpected error %q", err)
+		}
+		if string(b) != test.result {
+			t.Errorf("MarshalText %q: expected %q, got %q", test.v, test.result, string(b))
+		}
+	}
+}
+
+func TestTextUnmarshal(t *testing.T) {
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{"1.2.3", true},
+		{"3.1.4-alpha.1.5.9+build.2.6.5", true},
+		{"1.2", false},
+		{"", false},
+		{"1.2.3-01", false},
+	}
+	for _, test := range tests {
+		var v Version
+		err := v.UnmarshalText([]byte(test.input))
+		if test.valid && err != nil {
+			t.Errorf("%q: unexpected error %q", test.input, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%q: expected error", test.input)
+		} else if test.valid && v.String() != test.input {
+			t.Errorf("%q: unexpected result %q", test.input, v)
+		}
+	}
+}
+
+func TestPRVersionText(t *testing.T) {
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{"alpha", true},
+		{"123", true},
+		{"0", true},
+		{"01", false},
+		{"", false},
+		{"a.b", false},
+	}
+	for _, test := range tests {
+		var pr PRVersion
+		err := pr.UnmarshalText([]byte(test.input))
+		if test.valid && err != nil {
+			t.Errorf("%q: unexpected error %q", test.input, err)
+			continue
+		} else if !test.valid {
+			if err == nil {
+				t.Errorf("%q: expected error", test.input)
+			}
+			continue
+		}
+		b, err := pr.MarshalText()
+		if err != nil {
+			t.Errorf("%q: unexpected error %q", test.input, err)
+		}
+		if string(b) != test.input {
+			t.Errorf("%q: unexpected result %q", test.input, string(b))
+		}
+	}
+}
+
+func TestJSONMapKeys(t *testing.T) {
+	m := map[PRVersion]string{
+		{VersionStr: "beta"}:         "testing",
+		{VersionNum: 1, IsNum: true}: "nightly",
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"1":"nightly","beta":"testing"}`
+	if string(b) != expected {
+		t.Fatalf("Expected %s, got %s", expected, string(b))
+	}
+
+	var out map[PRVersion]string
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 2 || out[PRVersion{VersionStr: "beta"}] != "testing" || out[PRVersion{VersionNum: 1, IsNum: true}] != "nightly" {
+		t.Fatalf("Unexpected result %v", out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"01":"invalid"}`), &out); err == nil {
+		t.Fatal("Expected error for invalid map key")
+	}
+}
+
+func TestXML(t *testing.T) {
+	type release struct {
+		XMLName xml.Name  `xml:"release"`
+		Version Version   `xml:"version,attr"`
+		Pre     PRVersion `xml:"pre,attr"`
+		Latest  Version   `xml:"latest"`
+		Older   []Version `xml:"older"`
+	}
+	in := release{
+		Version: MustParse("1.2.3-rc.1+build.5"),
+		Pre:     PRVersion{VersionStr: "rc"},
+		Latest:  MustParse("2.0.0"),
+		Older:   []Version{MustParse("1.0.0"), MustParse("1.1.0")},
+	}
+	b, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<release version="1.2.3-rc.1+build.5" pre="rc"><latest>2.0.0</latest><older>1.0.0</older><older>1.1.0</older></release>`
+	if string(b) != expected {
+		t.Fatalf("Expected %s, got %s", expected, string(b))
+	}
+
+	var out release
+	if err := xml.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Version.EQ(in.Version) || out.Version.String() != in.Version.String() ||
+		out.Pre.Compare(in.Pre) != 0 || !out.Latest.EQ(in.Latest) || len(out.Older) != 2 {
+		t.Fatalf("Unexpected result %+v", out)
+	}
+
+	if err := xml.Unmarshal([]byte(`<release version="1.2"></release>`), &out); err == nil {
+		t.Fatal("Expected error for invalid attribute")
+	}
+	if err := xml.Unmarshal([]byte(`<release version="1.2.3"><latest>x</latest></release>`), &out); err == nil {
+		t.Fatal("Expected error for invalid element")
+	}
+}